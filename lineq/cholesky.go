@@ -7,8 +7,14 @@ import (
 	"github.com/angelsolaorbaiceta/inkmath/nums"
 )
 
-// CholeskyDecomposition computes the Cholesky lower matrix for a square, symmetric matrix.
-func CholeskyDecomposition(m mat.ReadOnlyMatrix) mat.ReadOnlyMatrix {
+/*
+CholeskyDecomposition computes the Cholesky lower matrix, L, for a square, symmetric matrix, such
+that A = L Lᵀ. The system A x = b can then be solved in two substitutions:
+
+	y := l.Solve(b)
+	x := l.SolveTransposed(y)
+*/
+func CholeskyDecomposition(m mat.ReadOnlyMatrix) *mat.LowerTriangular {
 	if !mat.IsSquare(m) {
 		panic("Cannot use Cholesky factorization in non-square matrices")
 	}
@@ -36,12 +42,12 @@ func CholeskyDecomposition(m mat.ReadOnlyMatrix) mat.ReadOnlyMatrix {
 		}
 	}
 
-	return lowerMatrix
+	return mat.MakeLowerTriangular(lowerMatrix, false)
 }
 
 // IncompleteCholeskyDecomposition computes the Incomplete Cholesky lower matrix decomposition
 // for the given square and symmetric matrix.
-func IncompleteCholeskyDecomposition(m mat.ReadOnlyMatrix) mat.ReadOnlyMatrix {
+func IncompleteCholeskyDecomposition(m mat.ReadOnlyMatrix) *mat.LowerTriangular {
 	if !mat.IsSquare(m) {
 		panic("Cannot use Cholesky factorization in non-square matrices")
 	}
@@ -72,5 +78,5 @@ func IncompleteCholeskyDecomposition(m mat.ReadOnlyMatrix) mat.ReadOnlyMatrix {
 		}
 	}
 
-	return lowerMatrix
+	return mat.MakeLowerTriangular(lowerMatrix, false)
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/angelsolaorbaiceta/inkmath/mat"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
 )
 
 func TestCholeskyDecomposition(t *testing.T) {
@@ -74,3 +75,16 @@ func makeCholeskyDecomposition() mat.ReadOnlyMatrix {
 
 	return m
 }
+
+func TestCholeskySolve(t *testing.T) {
+	var (
+		m        = makeCholeskyMatrix()
+		cholesky = CholeskyDecomposition(m)
+		want     = vec.MakeWithValues([]float64{1, -1, 2, 0.5})
+		b        = m.TimesVector(want)
+	)
+
+	if got := cholesky.SolveTransposed(cholesky.Solve(b)); !got.Equals(want) {
+		t.Errorf("Want %v, got %v", want, got)
+	}
+}
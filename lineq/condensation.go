@@ -0,0 +1,155 @@
+package lineq
+
+import (
+	"github.com/angelsolaorbaiceta/inkmath/mat"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+/*
+A StaticCondensation is the result of condensing the internal degrees of freedom (i) out of a
+system of equations, keeping only the boundary ones (b). The condensed matrix is the Schur
+complement of the internal block:
+
+	S = A_bb - A_bi A_ii⁻¹ A_ib
+
+and the condensed free terms are b_b - A_bi A_ii⁻¹ b_i. Once the boundary values are known, the
+internal ones are recovered as x_i = A_ii⁻¹ (b_i - A_ib x_b).
+*/
+type StaticCondensation struct {
+	boundary, internal []int
+	aii                *LU
+	abi, aib           *mat.View
+	complement         *mat.DenseMat
+}
+
+/*
+Condense computes the static condensation of the given matrix for a partition of its rows and
+columns into boundary and internal degrees of freedom, factorizing the internal block A_ii with
+LU. The order of the boundary degrees of freedom is that of the condensed matrix.
+
+It returns a mat.ZeroPivotError if the internal block is singular, whose index refers to the
+internal degrees of freedom. It panics if the indices aren't a partition of the matrix rows.
+*/
+func Condense(m mat.ReadOnlyMatrix, boundary, internal []int) (*StaticCondensation, error) {
+	if !mat.IsSquare(m) || len(boundary)+len(internal) != m.Rows() {
+		panic("Can't condense the matrix due to size mismatch")
+	}
+
+	seen := make([]bool, m.Rows())
+	for _, dofs := range [][]int{boundary, internal} {
+		for _, dof := range dofs {
+			if dof < 0 || dof >= m.Rows() || seen[dof] {
+				panic("Can't condense the matrix: boundary and internal indices aren't a partition")
+			}
+			seen[dof] = true
+		}
+	}
+
+	// The indices are copied, so that changes in the caller's slices don't affect the condensation
+	boundary, internal = copyIndices(boundary), copyIndices(internal)
+
+	aii, err := LUFactorize(mat.MakeView(m, internal, internal))
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		abi        = mat.MakeView(m, boundary, internal)
+		aib        = mat.MakeView(m, internal, boundary)
+		complement = mat.CopyToDense(mat.MakeView(m, boundary, boundary))
+		column     = vec.Make(len(internal))
+	)
+
+	for j := range boundary {
+		for i := range internal {
+			column.SetValue(i, aib.Value(i, j))
+		}
+
+		// y = A_ii⁻¹ A_ib(:, j), so the column j of S is A_bb(:, j) - A_bi y
+		y := aii.Solve(column)
+		for i := range boundary {
+			complement.AddToValue(i, j, -abi.RowTimesVector(i, y))
+		}
+	}
+
+	return &StaticCondensation{
+		boundary:   boundary,
+		internal:   internal,
+		aii:        aii,
+		abi:        abi,
+		aib:        aib,
+		complement: complement,
+	}, nil
+}
+
+// SchurComplement returns the condensed matrix, S = A_bb - A_bi A_ii⁻¹ A_ib.
+func (c *StaticCondensation) SchurComplement() mat.ReadOnlyMatrix {
+	return c.complement
+}
+
+// CondenseFreeTerms returns the condensed free terms, b_b - A_bi A_ii⁻¹ b_i, from the free terms
+// of the whole system.
+func (c *StaticCondensation) CondenseFreeTerms(b vec.ReadOnlyVector) vec.ReadOnlyVector {
+	c.assertSystemSize(b)
+
+	var (
+		y         = c.aii.Solve(vec.MakeView(b, c.internal))
+		freeTerms = vec.Make(len(c.boundary))
+	)
+
+	for i, dof := range c.boundary {
+		freeTerms.SetValue(i, b.Value(dof)-c.abi.RowTimesVector(i, y))
+	}
+
+	return freeTerms
+}
+
+/*
+RecoverInternal computes the values of the internal degrees of freedom, x_i = A_ii⁻¹ (b_i - A_ib x_b),
+from the free terms of the whole system and the values of the boundary degrees of freedom. The
+returned values are in the order of the internal degrees of freedom.
+*/
+func (c *StaticCondensation) RecoverInternal(
+	b vec.ReadOnlyVector,
+	boundaryValues vec.ReadOnlyVector,
+) vec.ReadOnlyVector {
+	c.assertSystemSize(b)
+	if boundaryValues.Length() != len(c.boundary) {
+		panic("Can't recover the internal values due to size mismatch")
+	}
+
+	return c.aii.Solve(vec.MakeView(b, c.internal).Minus(c.aib.TimesVector(boundaryValues)))
+}
+
+// Recover returns the values of all the degrees of freedom from the values of the boundary ones.
+func (c *StaticCondensation) Recover(
+	b vec.ReadOnlyVector,
+	boundaryValues vec.ReadOnlyVector,
+) vec.ReadOnlyVector {
+	var (
+		internalValues = c.RecoverInternal(b, boundaryValues)
+		solution       = vec.Make(b.Length())
+	)
+
+	for i, dof := range c.boundary {
+		solution.SetValue(dof, boundaryValues.Value(i))
+	}
+	for i, dof := range c.internal {
+		solution.SetValue(dof, internalValues.Value(i))
+	}
+
+	return solution
+}
+
+func copyIndices(indices []int) []int {
+	indicesCopy := make([]int, len(indices))
+	copy(indicesCopy, indices)
+
+	return indicesCopy
+}
+
+func (c *StaticCondensation) assertSystemSize(b vec.ReadOnlyVector) {
+	if b.Length() != len(c.boundary)+len(c.internal) {
+		panic("Can't use free terms whose size doesn't match the condensed system")
+	}
+}
@@ -0,0 +1,114 @@
+package lineq
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkmath/mat"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+func TestStaticCondensation(t *testing.T) {
+	var (
+		a = mat.MakeSparse(5, 5)
+		_ = makeSpringChain(a)
+		b = vec.MakeWithValues([]float64{1, 0, 3, 0, 2})
+		// Solution of the whole system, to compare with
+		want = LUSolver{}.Solve(a, b).Solution
+
+		boundary       = []int{4, 0}
+		internal       = []int{1, 2, 3}
+		condensed, err = Condense(a, boundary, internal)
+	)
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	t.Run("Schur complement", func(t *testing.T) {
+		// The inverse of the tridiagonal (-1, 2, -1) 3x3 matrix has 3/4 and 1/4 in its corners
+		want := []float64{
+			2 - 0.75, -0.25,
+			-0.25, 2 - 0.75,
+		}
+		if got := condensed.SchurComplement(); !mat.MatrixContainsData(got, want) {
+			t.Errorf("Want %v, got %v", want, got)
+		}
+	})
+
+	t.Run("condensed system has the boundary solution", func(t *testing.T) {
+		boundaryValues := LUSolver{}.Solve(condensed.SchurComplement(), condensed.CondenseFreeTerms(b)).Solution
+
+		for i, dof := range boundary {
+			if got := boundaryValues.Value(i); math.Abs(got-want.Value(dof)) > 1e-12 {
+				t.Errorf("Want %f at %d, got %f", want.Value(dof), dof, got)
+			}
+		}
+
+		internalValues := condensed.RecoverInternal(b, boundaryValues)
+		for i, dof := range internal {
+			if got := internalValues.Value(i); math.Abs(got-want.Value(dof)) > 1e-12 {
+				t.Errorf("Want %f at %d, got %f", want.Value(dof), dof, got)
+			}
+		}
+
+		solution := condensed.Recover(b, boundaryValues)
+		for dof := 0; dof < 5; dof++ {
+			if got := solution.Value(dof); math.Abs(got-want.Value(dof)) > 1e-12 {
+				t.Errorf("Want %f at %d, got %f", want.Value(dof), dof, got)
+			}
+		}
+	})
+}
+
+func TestStaticCondensationCopiesTheIndices(t *testing.T) {
+	var (
+		a        = mat.MakeSparse(3, 3)
+		_        = makeSpringChain(a)
+		b        = vec.MakeWithValues([]float64{1, 2, 3})
+		want     = LUSolver{}.Solve(a, b).Solution
+		boundary = []int{0, 2}
+		internal = []int{1}
+	)
+
+	condensed, err := Condense(a, boundary, internal)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	boundary[0], boundary[1], internal[0] = 1, 0, 2
+
+	boundaryValues := LUSolver{}.Solve(condensed.SchurComplement(), condensed.CondenseFreeTerms(b)).Solution
+	solution := condensed.Recover(b, boundaryValues)
+	for dof := 0; dof < 3; dof++ {
+		if got := solution.Value(dof); math.Abs(got-want.Value(dof)) > 1e-12 {
+			t.Errorf("Want %f at %d, got %f", want.Value(dof), dof, got)
+		}
+	}
+}
+
+func TestStaticCondensationSingularInternalBlock(t *testing.T) {
+	a := mat.MakeDenseWithData(3, 3, []float64{
+		2, 1, 1,
+		1, 0, 0,
+		1, 0, 0,
+	})
+
+	_, err := Condense(a, []int{0}, []int{1, 2})
+
+	var pivotErr mat.ZeroPivotError
+	if !errors.As(err, &pivotErr) {
+		t.Errorf("Expected a zero pivot error, got %v", err)
+	}
+}
+
+func TestStaticCondensationWrongPartition(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic")
+		}
+	}()
+
+	Condense(mat.MakeSparse(3, 3), []int{0, 1}, []int{1})
+}
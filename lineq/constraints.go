@@ -0,0 +1,180 @@
+package lineq
+
+import (
+	"github.com/angelsolaorbaiceta/inkmath/mat"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+/*
+A MultiPointConstraint defines the value of a slave degree of freedom as a linear combination of
+other (master) degrees of freedom plus an offset:
+
+	u_slave = Σ coefficient_i * u_master_i + offset
+
+Rigid links, inclined supports or prescribed values (a constraint without masters) can be
+expressed this way.
+*/
+type MultiPointConstraint struct {
+	Slave   int
+	Masters []ConstraintTerm
+	Offset  float64
+}
+
+// A ConstraintTerm is a master degree of freedom of a constraint, with its coefficient.
+type ConstraintTerm struct {
+	Dof         int
+	Coefficient float64
+}
+
+/*
+A ConstraintTransformation expresses all the degrees of freedom of a system, u, in terms of the
+retained ones (those which aren't slaves), u_r, as u = T u_r + g.
+
+The system A u = b is transformed to Tᵀ A T u_r = Tᵀ (b - A g), which keeps the symmetry of A and,
+being T sparse, its sparsity.
+*/
+type ConstraintTransformation struct {
+	t        *mat.SparseMat
+	g        vec.MutableVector
+	retained []int
+}
+
+/*
+MakeConstraintTransformation creates the transformation for a system with the given size and
+constraints.
+
+It panics if a degree of freedom is out of the system bounds, is constrained more than once, or a
+slave is used as the master of another constraint. Chained constraints should be resolved into
+constraints whose masters are retained degrees of freedom.
+*/
+func MakeConstraintTransformation(
+	size int,
+	constraints []MultiPointConstraint,
+) *ConstraintTransformation {
+	slaves := make(map[int]MultiPointConstraint, len(constraints))
+	for _, constraint := range constraints {
+		assertConstraintDof(constraint.Slave, size)
+		if _, isSlave := slaves[constraint.Slave]; isSlave {
+			panic("Can't constrain the same degree of freedom more than once")
+		}
+
+		slaves[constraint.Slave] = constraint
+	}
+
+	var (
+		retained = make([]int, 0, size-len(slaves))
+		// columns has, for each retained degree of freedom, its column in T.
+		columns = make(map[int]int, size-len(slaves))
+	)
+
+	for dof := 0; dof < size; dof++ {
+		if _, isSlave := slaves[dof]; !isSlave {
+			columns[dof] = len(retained)
+			retained = append(retained, dof)
+		}
+	}
+
+	var (
+		t = mat.MakeSparse(size, len(retained))
+		g = vec.Make(size)
+	)
+
+	for _, dof := range retained {
+		t.SetValue(dof, columns[dof], 1.0)
+	}
+
+	for slave, constraint := range slaves {
+		for _, master := range constraint.Masters {
+			assertConstraintDof(master.Dof, size)
+
+			col, isRetained := columns[master.Dof]
+			if !isRetained {
+				panic("Can't use a slave degree of freedom as master of another constraint")
+			}
+
+			t.AddToValue(slave, col, master.Coefficient)
+		}
+
+		g.SetValue(slave, constraint.Offset)
+	}
+
+	return &ConstraintTransformation{t, g, retained}
+}
+
+// Matrix returns the transformation matrix, T.
+func (c *ConstraintTransformation) Matrix() mat.ReadOnlyMatrix {
+	return c.t
+}
+
+// Offsets returns the vector of offsets, g.
+func (c *ConstraintTransformation) Offsets() vec.ReadOnlyVector {
+	return c.g
+}
+
+// RetainedDofs returns the degrees of freedom which aren't slaves, in the order they have in u_r.
+func (c *ConstraintTransformation) RetainedDofs() []int {
+	dofs := make([]int, len(c.retained))
+	copy(dofs, c.retained)
+
+	return dofs
+}
+
+// Transform returns the matrix, Tᵀ A T, and free terms, Tᵀ (b - A g), of the transformed system.
+func (c *ConstraintTransformation) Transform(
+	a mat.ReadOnlyMatrix,
+	b vec.ReadOnlyVector,
+) (mat.ReadOnlyMatrix, vec.ReadOnlyVector) {
+	if !mat.IsSquare(a) || a.Rows() != c.t.Rows() || b.Length() != c.t.Rows() {
+		panic("Can't transform the system due to size mismatch")
+	}
+
+	var (
+		residual  = b.Minus(a.TimesVector(c.g))
+		freeTerms = vec.Make(len(c.retained))
+	)
+
+	for row := 0; row < c.t.Rows(); row++ {
+		for _, col := range c.t.NonZeroIndicesAtRow(row) {
+			freeTerms.SetValue(col, freeTerms.Value(col)+c.t.Value(row, col)*residual.Value(row))
+		}
+	}
+
+	return mat.PtAP(c.t, a), freeTerms
+}
+
+// Recover returns all the degrees of freedom, T u_r + g, from the retained ones.
+func (c *ConstraintTransformation) Recover(retained vec.ReadOnlyVector) vec.ReadOnlyVector {
+	if retained.Length() != len(c.retained) {
+		panic("Can't recover the solution due to size mismatch")
+	}
+
+	return c.t.TimesVector(retained).Plus(c.g)
+}
+
+/*
+Solve solves the system A u = b subject to the constraints, using the given solver for the
+transformed system. The returned solution has all the degrees of freedom.
+*/
+func (c *ConstraintTransformation) Solve(
+	a mat.ReadOnlyMatrix,
+	b vec.ReadOnlyVector,
+	solver Solver,
+) *Solution {
+	var (
+		coefficients, freeTerms = c.Transform(a, b)
+		solution                = solver.Solve(coefficients, freeTerms)
+	)
+
+	return &Solution{
+		ReachedMaxIter: solution.ReachedMaxIter,
+		MinError:       solution.MinError,
+		IterCount:      solution.IterCount,
+		Solution:       c.Recover(solution.Solution),
+	}
+}
+
+func assertConstraintDof(dof, size int) {
+	if dof < 0 || dof >= size {
+		panic("Can't constrain a degree of freedom out of the system bounds")
+	}
+}
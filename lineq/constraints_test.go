@@ -0,0 +1,84 @@
+package lineq
+
+import (
+	"math"
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkmath/mat"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+func TestConstraintTransformation(t *testing.T) {
+	var (
+		a = mat.MakeSymSparse(3)
+		b = makeSpringChain(a)
+		// Fixed first node and rigid link between the second and third nodes
+		constraints = []MultiPointConstraint{
+			{Slave: 0, Offset: 0.1},
+			{Slave: 2, Masters: []ConstraintTerm{{Dof: 1, Coefficient: 1.0}}},
+		}
+		transformation = MakeConstraintTransformation(3, constraints)
+	)
+
+	t.Run("transformation matrix", func(t *testing.T) {
+		assertDofs(t, transformation.RetainedDofs(), []int{1})
+		if want := []float64{0, 1, 1}; !mat.MatrixContainsData(transformation.Matrix(), want) {
+			t.Errorf("Want %v, got %v", want, transformation.Matrix())
+		}
+
+		if want := []float64{0.1, 0, 0}; !vec.VectorContainsData(transformation.Offsets(), want) {
+			t.Errorf("Want offsets %v, got %v", want, transformation.Offsets())
+		}
+	})
+
+	t.Run("transformed system", func(t *testing.T) {
+		coefficients, freeTerms := transformation.Transform(a, b)
+
+		if want := []float64{2}; !mat.MatrixContainsData(coefficients, want) {
+			t.Errorf("Want %v, got %v", want, coefficients)
+		}
+		if got := freeTerms.Value(0); math.Abs(got-1.1) > 1e-12 {
+			t.Errorf("Want free term 1.1, got %f", got)
+		}
+	})
+
+	t.Run("solve", func(t *testing.T) {
+		solution := transformation.Solve(a, b, ConjugateGradientSolver{MaxError: 1e-10, MaxIter: 10})
+
+		for i, want := range []float64{0.1, 0.55, 0.55} {
+			if got := solution.Solution.Value(i); math.Abs(got-want) > 1e-10 {
+				t.Errorf("Want %f at %d, got %f", want, i, got)
+			}
+		}
+	})
+}
+
+func TestConstraintTransformationKeepsSymmetry(t *testing.T) {
+	var (
+		a = mat.MakeSparse(4, 4)
+		b = makeSpringChain(a)
+		// Inclined support: the last node slides along a line
+		transformation = MakeConstraintTransformation(4, []MultiPointConstraint{
+			{Slave: 0},
+			{Slave: 3, Masters: []ConstraintTerm{{Dof: 2, Coefficient: 0.5}}},
+		})
+		coefficients, _ = transformation.Transform(a, b)
+	)
+
+	if coefficients.Rows() != 2 || !mat.IsSymmetric(coefficients) {
+		t.Errorf("Expected a symmetric 2x2 matrix, got %v", coefficients)
+	}
+}
+
+func TestConstraintTransformationChainedSlaves(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic")
+		}
+	}()
+
+	MakeConstraintTransformation(3, []MultiPointConstraint{
+		{Slave: 1, Masters: []ConstraintTerm{{Dof: 0, Coefficient: 1.0}}},
+		{Slave: 2, Masters: []ConstraintTerm{{Dof: 1, Coefficient: 1.0}}},
+	})
+}
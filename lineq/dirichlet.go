@@ -0,0 +1,115 @@
+package lineq
+
+import (
+	"math"
+
+	"github.com/angelsolaorbaiceta/inkmath/mat"
+	"github.com/angelsolaorbaiceta/inkmath/nums"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+// A PrescribedDof is a degree of freedom whose value is known, like the displacement at a support.
+type PrescribedDof struct {
+	Dof   int
+	Value float64
+}
+
+// DefaultPenaltyFactor is a penalty factor that enforces the prescribed values with about eight
+// significant digits, while keeping the system well enough conditioned in double precision.
+const DefaultPenaltyFactor = 1e8
+
+/*
+ApplyDirichletElimination imposes the prescribed values on the system of equations by
+eliminating the rows and columns of their degrees of freedom, keeping the matrix symmetric.
+
+The known contributions of the prescribed columns, A(i, d) * value, are moved to the right-hand
+side of the free rows. Then, the rows and columns of the prescribed degrees of freedom are set to
+zero, except for the main diagonal, which keeps its value so that the conditioning of the matrix
+isn't affected (a zero diagonal is replaced by one). The right-hand side of the prescribed rows
+is set to the diagonal times the prescribed value, so the solution has the prescribed values.
+*/
+func ApplyDirichletElimination(a mat.MutableMatrix, b vec.MutableVector, prescribed []PrescribedDof) {
+	values := prescribedValues(a, b, prescribed)
+
+	for row := 0; row < a.Rows(); row++ {
+		if _, isPrescribed := values[row]; isPrescribed {
+			continue
+		}
+
+		rhs := b.Value(row)
+		mat.ForEachNonZeroInRow(a, row, func(col int, coefficient float64) {
+			if value, isPrescribed := values[col]; isPrescribed {
+				rhs -= coefficient * value
+			}
+		})
+		b.SetValue(row, rhs)
+	}
+
+	for _, dof := range prescribed {
+		diagonal := a.Value(dof.Dof, dof.Dof)
+		if nums.IsCloseToZero(diagonal) {
+			diagonal = 1.0
+		}
+
+		a.SetZeroCol(dof.Dof)
+		a.SetIdentityRow(dof.Dof)
+		a.SetValue(dof.Dof, dof.Dof, diagonal)
+		b.SetValue(dof.Dof, diagonal*dof.Value)
+	}
+}
+
+/*
+ApplyDirichletPenalty imposes the prescribed values on the system of equations using the penalty
+method: a large number is added to the main diagonal of each prescribed degree of freedom, and
+that number times the prescribed value to its right-hand side. Only the main diagonal is modified,
+so the matrix keeps its symmetry and sparsity pattern.
+
+The penalty is the penalty factor times the largest absolute value in the main diagonal. The
+prescribed values are only approximately satisfied: the larger the factor, the more accurate they
+are, but the worse the conditioning of the matrix. DefaultPenaltyFactor is a good compromise.
+*/
+func ApplyDirichletPenalty(
+	a mat.MutableMatrix,
+	b vec.MutableVector,
+	prescribed []PrescribedDof,
+	penaltyFactor float64,
+) {
+	prescribedValues(a, b, prescribed)
+
+	maxDiagonal := 0.0
+	for i := 0; i < a.Rows(); i++ {
+		maxDiagonal = math.Max(maxDiagonal, math.Abs(a.Value(i, i)))
+	}
+	if nums.IsCloseToZero(maxDiagonal) {
+		maxDiagonal = 1.0
+	}
+
+	penalty := penaltyFactor * maxDiagonal
+	for _, dof := range prescribed {
+		a.AddToValue(dof.Dof, dof.Dof, penalty)
+		b.SetValue(dof.Dof, b.Value(dof.Dof)+penalty*dof.Value)
+	}
+}
+
+// prescribedValues checks the sizes of the system and returns the prescribed values by degree of
+// freedom.
+func prescribedValues(
+	a mat.ReadOnlyMatrix,
+	b vec.ReadOnlyVector,
+	prescribed []PrescribedDof,
+) map[int]float64 {
+	if !mat.IsSquare(a) || a.Rows() != b.Length() {
+		panic("Can't apply the prescribed values due to size mismatch")
+	}
+
+	values := make(map[int]float64, len(prescribed))
+	for _, dof := range prescribed {
+		if dof.Dof < 0 || dof.Dof >= a.Rows() {
+			panic("Can't apply a prescribed value out of the system bounds")
+		}
+
+		values[dof.Dof] = dof.Value
+	}
+
+	return values
+}
@@ -0,0 +1,110 @@
+package lineq
+
+import (
+	"math"
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkmath/mat"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+var prescribedSpringChain = []PrescribedDof{{Dof: 0, Value: 1.0}, {Dof: 2, Value: 2.0}}
+
+func TestApplyDirichletElimination(t *testing.T) {
+	for _, a := range []mat.MutableMatrix{mat.MakeSparse(3, 3), mat.MakeSymSparse(3), mat.MakeSkyline(3)} {
+		b := makeSpringChain(a)
+
+		ApplyDirichletElimination(a, b, prescribedSpringChain)
+
+		if !mat.IsSymmetric(a) {
+			t.Errorf("Expected the matrix to be symmetric, got %v", a)
+		}
+		for i := 0; i < 3; i++ {
+			for j := 0; j < 3; j++ {
+				want := 0.0
+				if i == j {
+					want = 2.0
+				}
+				if got := a.Value(i, j); got != want {
+					t.Errorf("Want %f at (%d, %d), got %f", want, i, j, got)
+				}
+			}
+		}
+		if want := []float64{2, 4, 4}; !vec.VectorContainsData(b, want) {
+			t.Errorf("Want free terms %v, got %v", want, b)
+		}
+
+		solver := ConjugateGradientSolver{MaxError: 1e-10, MaxIter: 10}
+		if !solver.CanSolve(a, b) {
+			t.Fatal("Expected the system to be solvable with Conjugate Gradient")
+		}
+		if want := []float64{1, 2, 2}; !vec.VectorContainsData(solver.Solve(a, b).Solution, want) {
+			t.Errorf("Want solution %v, got %v", want, solver.Solve(a, b).Solution)
+		}
+	}
+}
+
+func TestApplyDirichletEliminationSmallUnits(t *testing.T) {
+	var (
+		a = mat.MakeDenseWithData(2, 2, []float64{2e-11, -1e-11, -1e-11, 2e-11})
+		b = vec.Make(2)
+	)
+
+	ApplyDirichletElimination(a, b, []PrescribedDof{{Dof: 0, Value: 1.0}})
+
+	if got := b.Value(1); got != 1e-11 {
+		t.Errorf("Want free term 1e-11, got %g", got)
+	}
+}
+
+func TestApplyDirichletPenalty(t *testing.T) {
+	var (
+		a = mat.MakeSymSparse(3)
+		b = makeSpringChain(a)
+	)
+
+	ApplyDirichletPenalty(a, b, prescribedSpringChain, DefaultPenaltyFactor)
+
+	if got := a.Value(1, 1); got != 2.0 {
+		t.Errorf("Expected the free diagonal to be unchanged, got %f", got)
+	}
+	if got := a.Value(0, 1); got != -1.0 {
+		t.Errorf("Expected the off-diagonal values to be unchanged, got %f", got)
+	}
+
+	solution := LUSolver{}.Solve(a, b).Solution
+	for i, want := range []float64{1, 2, 2} {
+		if got := solution.Value(i); math.Abs(got-want) > 1e-6 {
+			t.Errorf("Want %f at %d, got %f", want, i, got)
+		}
+	}
+}
+
+func TestApplyDirichletOutOfBounds(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic")
+		}
+	}()
+
+	a := mat.MakeSparse(3, 3)
+	ApplyDirichletElimination(a, makeSpringChain(a), []PrescribedDof{{Dof: 3, Value: 1.0}})
+}
+
+// makeSpringChain fills the matrix with the stiffness of a chain of springs, and returns the
+// free terms with a unit load in the middle.
+func makeSpringChain(a mat.MutableMatrix) vec.MutableVector {
+	size := a.Rows()
+	for i := 0; i < size; i++ {
+		a.SetValue(i, i, 2.0)
+		if i+1 < size {
+			a.SetValue(i, i+1, -1.0)
+			a.SetValue(i+1, i, -1.0)
+		}
+	}
+
+	b := vec.Make(size)
+	b.SetValue(size/2, 1.0)
+
+	return b
+}
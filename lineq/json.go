@@ -0,0 +1,60 @@
+package lineq
+
+import (
+	"encoding/json"
+
+	"github.com/angelsolaorbaiceta/inkmath/nums"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+type solutionJSON struct {
+	ReachedMaxIter bool             `json:"reachedMaxIter"`
+	MinError       nums.JSONFloat   `json:"minError"`
+	IterCount      int              `json:"iterCount"`
+	Solution       []nums.JSONFloat `json:"solution"`
+}
+
+/*
+MarshalJSON encodes the solution as a JSON object. NaN and infinite values, both in the error and
+the solution vector, are encoded as the "NaN", "+Inf" and "-Inf" strings.
+*/
+func (sol Solution) MarshalJSON() ([]byte, error) {
+	encoded := solutionJSON{
+		ReachedMaxIter: sol.ReachedMaxIter,
+		MinError:       nums.JSONFloat(sol.MinError),
+		IterCount:      sol.IterCount,
+	}
+
+	if sol.Solution != nil {
+		encoded.Solution = make([]nums.JSONFloat, sol.Solution.Length())
+		for i := range encoded.Solution {
+			encoded.Solution[i] = nums.JSONFloat(sol.Solution.Value(i))
+		}
+	}
+
+	return json.Marshal(encoded)
+}
+
+// UnmarshalJSON decodes a solution encoded with MarshalJSON, overwriting this solution.
+func (sol *Solution) UnmarshalJSON(data []byte) error {
+	var decoded solutionJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	sol.ReachedMaxIter = decoded.ReachedMaxIter
+	sol.MinError = float64(decoded.MinError)
+	sol.IterCount = decoded.IterCount
+	sol.Solution = nil
+
+	if decoded.Solution != nil {
+		values := make([]float64, len(decoded.Solution))
+		for i, val := range decoded.Solution {
+			values[i] = float64(val)
+		}
+
+		sol.Solution = vec.MakeWithValues(values)
+	}
+
+	return nil
+}
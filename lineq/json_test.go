@@ -0,0 +1,36 @@
+package lineq
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+func TestSolutionJSONEncoding(t *testing.T) {
+	var (
+		sol  = makeErrorSolution(50, math.Inf(1), vec.MakeWithValues([]float64{1, math.NaN()}))
+		want = `{"reachedMaxIter":true,"minError":"+Inf","iterCount":50,"solution":[1,"NaN"]}`
+	)
+
+	encoded, err := json.Marshal(sol)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(encoded) != want {
+		t.Errorf("Want %s, got %s", want, encoded)
+	}
+
+	var decoded Solution
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !decoded.ReachedMaxIter || decoded.IterCount != 50 || !math.IsInf(decoded.MinError, 1) {
+		t.Errorf("Wrong decoded solution: %v", decoded)
+	}
+	if decoded.Solution.Value(0) != 1 || !math.IsNaN(decoded.Solution.Value(1)) {
+		t.Errorf("Wrong decoded solution vector: %v", decoded.Solution)
+	}
+}
@@ -0,0 +1,270 @@
+package lineq
+
+import (
+	"math"
+
+	"github.com/angelsolaorbaiceta/inkmath/mat"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+/*
+luPivotTolerance is the size of a pivot, relative to the largest absolute value of the original
+matrix, below which the pivot is considered zero.
+*/
+const luPivotTolerance = 1e-14
+
+/*
+An LU is the factorization with partial pivoting of a square matrix, P A = L U, where P is a
+permutation matrix, L a unit lower triangular matrix and U an upper triangular matrix.
+
+The factors are stored in a dense array, so the factorization is meant for small to medium sized
+systems, or those which aren't symmetric and can't be solved with the Cholesky or LDLᵀ methods.
+*/
+type LU struct {
+	size int
+	// lu has the values of L below the main diagonal and those of U above and on it, row by row.
+	lu []float64
+	// pivots has, for each row of the factors, the row of the original matrix.
+	pivots []int
+	// sign is the sign of the determinant of P: -1 for an odd number of row swaps.
+	sign float64
+}
+
+/*
+LUFactorize computes the LU factorization, with partial pivoting, of any square matrix.
+
+A mat.ZeroPivotError is returned if the matrix is singular, that is, if no pivot larger than a
+small fraction of the largest absolute value of the matrix can be found.
+*/
+func LUFactorize(m mat.ReadOnlyMatrix) (*LU, error) {
+	if !mat.IsSquare(m) {
+		panic("Cannot use LU factorization in non-square matrices")
+	}
+
+	var (
+		size     = m.Rows()
+		lu       = make([]float64, size*size)
+		pivots   = make([]int, size)
+		sign     = 1.0
+		maxValue = 0.0
+	)
+
+	// Every value is copied, as the factors are dense and NonZeroIndicesAtRow would skip the
+	// small values of matrices in small units
+	for row := 0; row < size; row++ {
+		pivots[row] = row
+		for col := 0; col < size; col++ {
+			value := m.Value(row, col)
+			lu[row*size+col] = value
+			maxValue = math.Max(maxValue, math.Abs(value))
+		}
+	}
+
+	tolerance := luPivotTolerance * maxValue
+
+	for k := 0; k < size; k++ {
+		// Partial pivoting: swap the row with the largest value in column k
+		pivotRow := k
+		for i := k + 1; i < size; i++ {
+			if math.Abs(lu[i*size+k]) > math.Abs(lu[pivotRow*size+k]) {
+				pivotRow = i
+			}
+		}
+
+		if math.Abs(lu[pivotRow*size+k]) <= tolerance {
+			return nil, mat.ZeroPivotError{Index: k}
+		}
+
+		if pivotRow != k {
+			for j := 0; j < size; j++ {
+				lu[k*size+j], lu[pivotRow*size+j] = lu[pivotRow*size+j], lu[k*size+j]
+			}
+			pivots[k], pivots[pivotRow] = pivots[pivotRow], pivots[k]
+			sign = -sign
+		}
+
+		// Elimination of the values below the pivot
+		pivot := lu[k*size+k]
+		for i := k + 1; i < size; i++ {
+			factor := lu[i*size+k] / pivot
+			if factor == 0.0 {
+				continue
+			}
+
+			lu[i*size+k] = factor
+			for j := k + 1; j < size; j++ {
+				lu[i*size+j] -= factor * lu[k*size+j]
+			}
+		}
+	}
+
+	return &LU{size, lu, pivots, sign}, nil
+}
+
+// Size returns the number of rows (and columns) of the factorized matrix.
+func (f LU) Size() int {
+	return f.size
+}
+
+/*
+Pivots returns the row permutation of the factorization: the i-th row of the factors corresponds
+to the row Pivots()[i] of the original matrix.
+*/
+func (f LU) Pivots() []int {
+	pivots := make([]int, f.size)
+	copy(pivots, f.pivots)
+
+	return pivots
+}
+
+// Solve solves the system A x = b using the factorization of A.
+func (f LU) Solve(b vec.ReadOnlyVector) vec.ReadOnlyVector {
+	if f.size != b.Length() {
+		panic("Can't solve system due to size mismatch")
+	}
+
+	x := make([]float64, f.size)
+	for i, row := range f.pivots {
+		x[i] = b.Value(row)
+	}
+
+	// Forward substitution: L y = P b
+	for i := 0; i < f.size; i++ {
+		for j := 0; j < i; j++ {
+			x[i] -= f.lu[i*f.size+j] * x[j]
+		}
+	}
+
+	// Backward substitution: U x = y
+	for i := f.size - 1; i >= 0; i-- {
+		for j := i + 1; j < f.size; j++ {
+			x[i] -= f.lu[i*f.size+j] * x[j]
+		}
+		x[i] /= f.lu[i*f.size+i]
+	}
+
+	return vec.MakeWithValues(x)
+}
+
+// SolveTransposed solves the system Aᵀ x = b using the factorization of A.
+func (f LU) SolveTransposed(b vec.ReadOnlyVector) vec.ReadOnlyVector {
+	if f.size != b.Length() {
+		panic("Can't solve system due to size mismatch")
+	}
+
+	z := make([]float64, f.size)
+	for i := range z {
+		z[i] = b.Value(i)
+	}
+
+	// Forward substitution: Uᵀ w = b
+	for i := 0; i < f.size; i++ {
+		for j := 0; j < i; j++ {
+			z[i] -= f.lu[j*f.size+i] * z[j]
+		}
+		z[i] /= f.lu[i*f.size+i]
+	}
+
+	// Backward substitution: Lᵀ z = w
+	for i := f.size - 1; i >= 0; i-- {
+		for j := i + 1; j < f.size; j++ {
+			z[i] -= f.lu[j*f.size+i] * z[j]
+		}
+	}
+
+	// Undo the permutation: x = Pᵀ z
+	x := vec.Make(f.size)
+	for i, row := range f.pivots {
+		x.SetValue(row, z[i])
+	}
+
+	return x
+}
+
+// Det returns the determinant of the factorized matrix.
+func (f LU) Det() float64 {
+	det := f.sign
+	for i := 0; i < f.size; i++ {
+		det *= f.lu[i*f.size+i]
+	}
+
+	return det
+}
+
+/*
+LogDet returns the natural logarithm of the absolute value of the determinant and its sign. Use it
+instead of Det for large matrices, whose determinant may overflow or underflow.
+*/
+func (f LU) LogDet() (logAbsDet, sign float64) {
+	sign = f.sign
+	for i := 0; i < f.size; i++ {
+		diag := f.lu[i*f.size+i]
+		if diag < 0 {
+			sign = -sign
+		}
+
+		logAbsDet += math.Log(math.Abs(diag))
+	}
+
+	return logAbsDet, sign
+}
+
+// Inverse returns the inverse of the factorized matrix as a dense matrix.
+func (f LU) Inverse() mat.MutableMatrix {
+	var (
+		inverse = mat.MakeSquareDense(f.size)
+		unit    = vec.Make(f.size)
+	)
+
+	for col := 0; col < f.size; col++ {
+		unit.SetValue(col, 1.0)
+		x := f.Solve(unit)
+		unit.SetZero(col)
+
+		for row := 0; row < f.size; row++ {
+			inverse.SetValue(row, col, x.Value(row))
+		}
+	}
+
+	return inverse
+}
+
+/*
+LUSolver is a direct solver for square systems of linear equations, which don't need to be
+symmetric, using the LU factorization with partial pivoting.
+*/
+type LUSolver struct{}
+
+// CanSolve returns whether the system matrix is square and has the same size as the vector.
+func (solver LUSolver) CanSolve(
+	coefficients mat.ReadOnlyMatrix,
+	freeTerms vec.ReadOnlyVector,
+) bool {
+	return mat.IsSquare(coefficients) && coefficients.Rows() == freeTerms.Length()
+}
+
+/*
+Solve solves the system of equations using the LU factorization of the system matrix. The
+solution error is the largest absolute value of the residual, b - A x.
+
+If the matrix is singular, the returned solution has ReachedMaxIter set, an infinite error and a
+zero solution vector.
+*/
+func (solver LUSolver) Solve(a mat.ReadOnlyMatrix, b vec.ReadOnlyVector) *Solution {
+	lu, err := LUFactorize(a)
+	if err != nil {
+		return makeErrorSolution(0, math.Inf(1), vec.Make(b.Length()))
+	}
+
+	var (
+		x        = lu.Solve(b)
+		residual = b.Minus(a.TimesVector(x))
+		maxError = 0.0
+	)
+
+	for i := 0; i < residual.Length(); i++ {
+		maxError = math.Max(maxError, math.Abs(residual.Value(i)))
+	}
+
+	return makeSolution(0, maxError, x)
+}
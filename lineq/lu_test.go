@@ -0,0 +1,161 @@
+package lineq
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkmath/mat"
+	"github.com/angelsolaorbaiceta/inkmath/nums"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+func TestLUFactorization(t *testing.T) {
+	// Non symmetric matrix which needs pivoting, as the first value is zero
+	var (
+		m = mat.MakeSparseWithData(3, 3, []float64{
+			0, 2, 1,
+			1, 1, 1,
+			4, 3, 1,
+		})
+		want = vec.MakeWithValues([]float64{1, 2, 3})
+		b    = m.TimesVector(want)
+	)
+
+	lu, err := LUFactorize(m)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	t.Run("solve", func(t *testing.T) {
+		if got := lu.Solve(b); !got.Equals(want) {
+			t.Errorf("Want %v, got %v", want, got)
+		}
+	})
+
+	t.Run("solve transposed", func(t *testing.T) {
+		bT := mat.Transpose(m).TimesVector(want)
+		if got := lu.SolveTransposed(bT); !got.Equals(want) {
+			t.Errorf("Want %v, got %v", want, got)
+		}
+	})
+
+	t.Run("pivots", func(t *testing.T) {
+		pivots := lu.Pivots()
+		if len(pivots) != 3 || pivots[0] != 2 {
+			t.Errorf("Want the third row as first pivot, got %v", pivots)
+		}
+
+		pivots[0] = 0
+		if lu.Pivots()[0] != 2 {
+			t.Error("Pivots should return a copy")
+		}
+	})
+
+	t.Run("determinant", func(t *testing.T) {
+		if got := lu.Det(); !nums.FloatsEqual(got, 5.0) {
+			t.Errorf("Want determinant 5, got %f", got)
+		}
+
+		logDet, sign := lu.LogDet()
+		if !nums.FloatsEqual(logDet, math.Log(5.0)) || sign != 1.0 {
+			t.Errorf("Want log determinant %f and sign 1, got %f and %f", math.Log(5.0), logDet, sign)
+		}
+	})
+
+	t.Run("negative determinant", func(t *testing.T) {
+		lu, _ := LUFactorize(mat.MakeDenseWithData(2, 2, []float64{0, 1, 1, 0}))
+
+		if got := lu.Det(); got != -1.0 {
+			t.Errorf("Want determinant -1, got %f", got)
+		}
+		if logDet, sign := lu.LogDet(); logDet != 0.0 || sign != -1.0 {
+			t.Errorf("Want log determinant 0 and sign -1, got %f and %f", logDet, sign)
+		}
+	})
+
+	t.Run("inverse", func(t *testing.T) {
+		product := m.TimesMatrix(lu.Inverse())
+		if !mat.AreEqual(product, mat.MakeIdentity(3)) {
+			t.Errorf("Want the identity, got %v", product)
+		}
+	})
+
+	t.Run("condition number", func(t *testing.T) {
+		var (
+			inverse = lu.Inverse()
+			want    = mat.NormOne(m) * mat.NormOne(inverse)
+		)
+
+		if got := mat.EstimateCondOne(m, lu); !nums.FloatsEqual(got, want) {
+			t.Errorf("Want condition number %f, got %f", want, got)
+		}
+	})
+}
+
+func TestLUFactorizationSingularMatrix(t *testing.T) {
+	m := mat.MakeDenseWithData(3, 3, []float64{
+		1, 2, 3,
+		2, 4, 6,
+		1, 0, 1,
+	})
+
+	_, err := LUFactorize(m)
+
+	var pivotErr mat.ZeroPivotError
+	if !errors.As(err, &pivotErr) {
+		t.Fatalf("Want a ZeroPivotError, got %v", err)
+	}
+	if pivotErr.Index != 2 {
+		t.Errorf("Want the zero pivot at index 2, got %d", pivotErr.Index)
+	}
+
+	if _, err := LUFactorize(mat.MakeSparse(2, 2)); err == nil {
+		t.Error("Expected an error factorizing a zero matrix")
+	}
+}
+
+func TestLUFactorizationSmallValues(t *testing.T) {
+	m := mat.MakeDenseWithData(3, 3, []float64{
+		2e-11, 1e-11, 0,
+		1e-11, 2e-11, 0,
+		0, 0, 1e-11,
+	})
+
+	lu, err := LUFactorize(m)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var (
+		want = vec.MakeWithValues([]float64{1, 2, 3})
+		got  = lu.Solve(vec.MakeWithValues([]float64{4e-11, 5e-11, 3e-11}))
+	)
+	if !got.Equals(want) {
+		t.Errorf("Want %v, got %v", want, got)
+	}
+}
+
+func TestLUSolver(t *testing.T) {
+	var (
+		m, v   = makeSystem2x2()
+		solver = LUSolver{}
+	)
+
+	if !solver.CanSolve(m, v) {
+		t.Fatal("Expected the LU solver to solve the system")
+	}
+
+	sol := solver.Solve(m, v)
+	if !sol.Solution.Equals(expectedSol2x2) {
+		t.Errorf("Wrong solution, Expected %v, but got %v", expectedSol2x2, sol)
+	}
+	if sol.ReachedMaxIter || sol.MinError > 1e-10 {
+		t.Errorf("Wrong solution error: %f", sol.MinError)
+	}
+
+	sol = solver.Solve(mat.MakeSparse(2, 2), v)
+	if !sol.ReachedMaxIter || !math.IsInf(sol.MinError, 1) {
+		t.Errorf("Expected an error solution for a singular matrix, got %v", sol)
+	}
+}
@@ -0,0 +1,173 @@
+package lineq
+
+import (
+	"sort"
+
+	"github.com/angelsolaorbaiceta/inkmath/mat"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+/*
+A ReducedSystem is the system of equations of the free degrees of freedom, that results from
+removing the degrees of freedom with prescribed values from a system A x = b. Partitioning the
+degrees of freedom into free (f) and fixed (s), the reduced system is:
+
+	A_ff x_f = b_f - A_fs x_s
+
+The original system isn't modified, and the reduced matrix is a sparse copy of the free rows and
+columns, which keeps the symmetry of the original matrix. When the original matrix is symmetric by
+construction, like a SymSparseMat or a SkylineMat, the copy is a SymSparseMat.
+*/
+type ReducedSystem struct {
+	coefficients mat.ReadOnlyMatrix
+	freeTerms    vec.ReadOnlyVector
+	// fixedValues has the prescribed values by degree of freedom.
+	fixedValues      map[int]float64
+	freeDofs         []int
+	reducedMatrix    mat.ReadOnlyMatrix
+	reducedFreeTerms vec.MutableVector
+}
+
+/*
+A ReducedSolution is the solution of a ReducedSystem, expanded back to all the degrees of freedom
+of the original system.
+*/
+type ReducedSolution struct {
+	// Reduced is the solver's solution of the reduced system.
+	Reduced *Solution
+	// FullSolution has the values of all degrees of freedom: the computed and the prescribed ones.
+	FullSolution vec.ReadOnlyVector
+	/*
+		Reactions has, for each fixed degree of freedom, A_sf x_f + A_ss x_s - b_s, which is the
+		force needed at the support to keep the prescribed value. It's zero for the free ones.
+	*/
+	Reactions vec.ReadOnlyVector
+}
+
+/*
+MakeReducedSystem builds the reduced system of the free degrees of freedom for the system of
+equations with coefficients a and free terms b, and the given prescribed values.
+
+It panics if the system isn't square or any of the prescribed degrees of freedom is out of the
+system bounds.
+*/
+func MakeReducedSystem(
+	a mat.ReadOnlyMatrix,
+	b vec.ReadOnlyVector,
+	prescribed []PrescribedDof,
+) *ReducedSystem {
+	var (
+		fixedValues = prescribedValues(a, b, prescribed)
+		freeDofs    = make([]int, 0, a.Rows()-len(fixedValues))
+	)
+
+	for dof := 0; dof < a.Rows(); dof++ {
+		if _, isFixed := fixedValues[dof]; !isFixed {
+			freeDofs = append(freeDofs, dof)
+		}
+	}
+
+	reducedFreeTerms := vec.Make(len(freeDofs))
+	for i, row := range freeDofs {
+		value := b.Value(row)
+		mat.ForEachNonZeroInRow(a, row, func(col int, coefficient float64) {
+			if fixedValue, isFixed := fixedValues[col]; isFixed {
+				value -= coefficient * fixedValue
+			}
+		})
+
+		reducedFreeTerms.SetValue(i, value)
+	}
+
+	var (
+		freeView      = mat.MakeView(a, freeDofs, freeDofs)
+		reducedMatrix mat.ReadOnlyMatrix
+	)
+	if mat.IsSymmetricByConstruction(a) {
+		reducedMatrix = mat.CopyToSymSparse(freeView)
+	} else {
+		reducedMatrix = mat.CopyToSparse(freeView)
+	}
+
+	return &ReducedSystem{
+		coefficients:     a,
+		freeTerms:        b,
+		fixedValues:      fixedValues,
+		freeDofs:         freeDofs,
+		reducedMatrix:    reducedMatrix,
+		reducedFreeTerms: reducedFreeTerms,
+	}
+}
+
+// Coefficients returns the matrix of the reduced system, A_ff.
+func (s *ReducedSystem) Coefficients() mat.ReadOnlyMatrix {
+	return s.reducedMatrix
+}
+
+// FreeTerms returns the free terms of the reduced system, b_f - A_fs x_s.
+func (s *ReducedSystem) FreeTerms() vec.ReadOnlyVector {
+	return s.reducedFreeTerms
+}
+
+// FreeDofs returns the degrees of freedom of the original system in the reduced one, in order.
+func (s *ReducedSystem) FreeDofs() []int {
+	dofs := make([]int, len(s.freeDofs))
+	copy(dofs, s.freeDofs)
+
+	return dofs
+}
+
+// FixedDofs returns the prescribed degrees of freedom of the original system, in order.
+func (s *ReducedSystem) FixedDofs() []int {
+	dofs := make([]int, 0, len(s.fixedValues))
+	for dof := range s.fixedValues {
+		dofs = append(dofs, dof)
+	}
+	sort.Ints(dofs)
+
+	return dofs
+}
+
+/*
+Solve solves the reduced system with the given solver, and expands its solution to all the degrees
+of freedom, computing the reactions of the fixed ones. Use the solver's CanSolve method with the
+reduced Coefficients and FreeTerms to check that the solver is suitable.
+*/
+func (s *ReducedSystem) Solve(solver Solver) *ReducedSolution {
+	var (
+		solution     = solver.Solve(s.reducedMatrix, s.reducedFreeTerms)
+		fullSolution = s.Expand(solution.Solution)
+		reactions    = vec.Make(s.coefficients.Rows())
+	)
+
+	for dof := range s.fixedValues {
+		reactions.SetValue(
+			dof,
+			s.coefficients.RowTimesVector(dof, fullSolution)-s.freeTerms.Value(dof),
+		)
+	}
+
+	return &ReducedSolution{
+		Reduced:      solution,
+		FullSolution: fullSolution,
+		Reactions:    reactions,
+	}
+}
+
+// Expand returns the full length vector with the values of the free degrees of freedom from the
+// reduced solution and the prescribed values of the fixed ones.
+func (s *ReducedSystem) Expand(reducedSolution vec.ReadOnlyVector) vec.ReadOnlyVector {
+	if reducedSolution.Length() != len(s.freeDofs) {
+		panic("Can't expand the reduced solution due to size mismatch")
+	}
+
+	fullSolution := vec.Make(s.coefficients.Rows())
+	for i, dof := range s.freeDofs {
+		fullSolution.SetValue(dof, reducedSolution.Value(i))
+	}
+	for dof, value := range s.fixedValues {
+		fullSolution.SetValue(dof, value)
+	}
+
+	return fullSolution
+}
@@ -0,0 +1,106 @@
+package lineq
+
+import (
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkmath/mat"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+func TestReducedSystem(t *testing.T) {
+	var (
+		a        = mat.MakeSymSparse(5)
+		b        = makeSpringChain(a)
+		supports = []PrescribedDof{{Dof: 4, Value: 0.0}, {Dof: 0, Value: 0.0}}
+		system   = MakeReducedSystem(a, b, supports)
+	)
+
+	t.Run("partitions the degrees of freedom", func(t *testing.T) {
+		assertDofs(t, system.FreeDofs(), []int{1, 2, 3})
+		assertDofs(t, system.FixedDofs(), []int{0, 4})
+	})
+
+	t.Run("builds the reduced system", func(t *testing.T) {
+		coefficients := system.Coefficients()
+		if coefficients.Rows() != 3 || coefficients.Cols() != 3 {
+			t.Fatalf("Want a 3x3 matrix, got %dx%d", coefficients.Rows(), coefficients.Cols())
+		}
+		if _, ok := coefficients.(*mat.SymSparseMat); !ok {
+			t.Errorf("Expected the reduced matrix to keep the symmetric storage, got %T", coefficients)
+		}
+		if want := []float64{0, 1, 0}; !vec.VectorContainsData(system.FreeTerms(), want) {
+			t.Errorf("Want free terms %v, got %v", want, system.FreeTerms())
+		}
+	})
+
+	t.Run("solves and computes the reactions", func(t *testing.T) {
+		solution := system.Solve(ConjugateGradientSolver{MaxError: 1e-10, MaxIter: 10})
+
+		if solution.Reduced.ReachedMaxIter {
+			t.Error("Expected the solver to converge")
+		}
+		if want := []float64{0, 0.5, 1, 0.5, 0}; !vec.VectorContainsData(solution.FullSolution, want) {
+			t.Errorf("Want solution %v, got %v", want, solution.FullSolution)
+		}
+		if want := []float64{-0.5, 0, 0, 0, -0.5}; !vec.VectorContainsData(solution.Reactions, want) {
+			t.Errorf("Want reactions %v, got %v", want, solution.Reactions)
+		}
+	})
+
+	t.Run("doesn't modify the original system", func(t *testing.T) {
+		if a.Value(0, 0) != 2.0 || a.Value(0, 1) != -1.0 || b.Value(0) != 0.0 {
+			t.Error("Expected the original system to be unchanged")
+		}
+	})
+}
+
+func TestReducedSystemSmallUnits(t *testing.T) {
+	var (
+		a = mat.MakeDenseWithData(3, 3, []float64{
+			2e-11, -1e-11, 0,
+			-1e-11, 2e-11, -1e-11,
+			0, -1e-11, 2e-11,
+		})
+		system = MakeReducedSystem(a, vec.Make(3), []PrescribedDof{{Dof: 0, Value: 1.0}})
+	)
+
+	if got := system.Coefficients().Value(0, 1); got != -1e-11 {
+		t.Errorf("Want reduced coefficient -1e-11, got %g", got)
+	}
+	if got := system.FreeTerms().Value(0); got != 1e-11 {
+		t.Errorf("Want free term 1e-11, got %g", got)
+	}
+}
+
+func TestReducedSystemWithPrescribedValues(t *testing.T) {
+	var (
+		a        = mat.MakeSparse(3, 3)
+		b        = makeSpringChain(a)
+		system   = MakeReducedSystem(a, b, prescribedSpringChain)
+		solution = system.Solve(LUSolver{})
+	)
+
+	if want := []float64{4}; !vec.VectorContainsData(system.FreeTerms(), want) {
+		t.Errorf("Want free terms %v, got %v", want, system.FreeTerms())
+	}
+	if want := []float64{1, 2, 2}; !vec.VectorContainsData(solution.FullSolution, want) {
+		t.Errorf("Want solution %v, got %v", want, solution.FullSolution)
+	}
+	if want := []float64{0, 0, 2}; !vec.VectorContainsData(solution.Reactions, want) {
+		t.Errorf("Want reactions %v, got %v", want, solution.Reactions)
+	}
+}
+
+func assertDofs(t *testing.T, got, want []int) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("Want %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Want %v, got %v", want, got)
+			return
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package mat
+
+import "container/heap"
+
+/*
+ApproximateMinimumDegree computes a fill-reducing ordering of a symmetric matrix, to be used
+before factorizing it with a sparse direct method like Cholesky.
+
+The ordering is computed from the sparsity pattern of the matrix (which is symmetrized) by
+simulating the elimination of the rows in a quotient graph, where the eliminated rows become
+elements representing the cliques they generate. At each step, the row with the smallest
+approximate external degree is eliminated, using the degree bounds of the AMD algorithm by
+Amestoy, Davis and Duff, which are much cheaper to update than the exact degrees.
+
+Use AnalyzeCholesky on the permuted matrix to predict the number of non-zero values of the
+Cholesky factor, and compare it with other orderings:
+
+	ordering := mat.ApproximateMinimumDegree(m)
+	nnz := mat.AnalyzeCholesky(mat.MakeView(m, ordering.Permutation, ordering.Permutation)).NonZeroCount()
+*/
+func ApproximateMinimumDegree(m ReadOnlyMatrix) *Ordering {
+	if !IsSquare(m) {
+		panic("Can't compute the ordering of a non-square matrix")
+	}
+
+	var (
+		size  = m.Rows()
+		graph = makeSparsityGraph(m)
+		qg    = makeQuotientGraph(graph)
+		queue = make(degreeQueue, 0, size)
+		// permutation has the rows in the order they're eliminated, which is the new order.
+		permutation = make([]int, 0, size)
+	)
+
+	for node := 0; node < size; node++ {
+		heap.Push(&queue, degreeEntry{node, qg.degree[node]})
+	}
+
+	for queue.Len() > 0 {
+		entry := heap.Pop(&queue).(degreeEntry)
+		if qg.eliminated[entry.node] || entry.degree != qg.degree[entry.node] {
+			// Stale entry, whose degree has been updated after pushing it
+			continue
+		}
+
+		permutation = append(permutation, entry.node)
+		for _, node := range qg.eliminate(entry.node, size-len(permutation)) {
+			heap.Push(&queue, degreeEntry{node, qg.degree[node]})
+		}
+	}
+
+	permuted := MakeView(m, permutation, permutation)
+
+	return &Ordering{
+		Permutation:     permutation,
+		BandwidthBefore: Bandwidth(m),
+		BandwidthAfter:  Bandwidth(permuted),
+		ProfileBefore:   Profile(m),
+		ProfileAfter:    Profile(permuted),
+	}
+}
+
+/*
+A quotientGraph represents the graph of the matrix being eliminated. The eliminated rows become
+elements, which are connected to the non-eliminated rows (variables) in the clique that their
+elimination creates. Two variables are adjacent if they're connected directly or through an
+element.
+*/
+type quotientGraph struct {
+	// variables has, for each variable, its adjacent variables not covered by an element.
+	variables []map[int]bool
+	// elements has, for each variable, its adjacent elements.
+	elements []map[int]bool
+	// members has, for each element (indexed by the eliminated row), its variables.
+	members    map[int]map[int]bool
+	degree     []int
+	eliminated []bool
+}
+
+func makeQuotientGraph(graph sparsityGraph) *quotientGraph {
+	var (
+		size = len(graph)
+		qg   = &quotientGraph{
+			variables:  make([]map[int]bool, size),
+			elements:   make([]map[int]bool, size),
+			members:    make(map[int]map[int]bool),
+			degree:     make([]int, size),
+			eliminated: make([]bool, size),
+		}
+	)
+
+	for node, neighbors := range graph {
+		qg.variables[node] = make(map[int]bool, len(neighbors))
+		qg.elements[node] = make(map[int]bool)
+		qg.degree[node] = len(neighbors)
+
+		for _, neighbor := range neighbors {
+			qg.variables[node][neighbor] = true
+		}
+	}
+
+	return qg
+}
+
+/*
+eliminate eliminates the pivot variable, turning it into a new element which absorbs the elements
+adjacent to it, and updates the approximate degrees of the variables in the new element, which
+are returned. The remaining argument is the number of variables left after the elimination, which
+bounds the degrees.
+*/
+func (qg *quotientGraph) eliminate(pivot, remaining int) []int {
+	qg.eliminated[pivot] = true
+
+	// The new element has the variables adjacent to the pivot, directly or through its elements
+	newMembers := make(map[int]bool)
+	for variable := range qg.variables[pivot] {
+		if !qg.eliminated[variable] {
+			newMembers[variable] = true
+		}
+	}
+	for element := range qg.elements[pivot] {
+		for variable := range qg.members[element] {
+			if variable != pivot {
+				newMembers[variable] = true
+			}
+
+			// The absorbed element is replaced by the new one in its variables
+			delete(qg.elements[variable], element)
+		}
+
+		delete(qg.members, element)
+	}
+
+	qg.members[pivot] = newMembers
+	qg.variables[pivot] = nil
+	qg.elements[pivot] = nil
+
+	for variable := range newMembers {
+		qg.elements[variable][pivot] = true
+		delete(qg.variables[variable], pivot)
+
+		// The variables in the new element are now connected through it
+		for other := range newMembers {
+			delete(qg.variables[variable], other)
+		}
+	}
+
+	// External sizes of the other elements: the number of their variables not in the new element
+	external := make(map[int]int)
+	for variable := range newMembers {
+		for element := range qg.elements[variable] {
+			if element == pivot {
+				continue
+			}
+
+			if _, ok := external[element]; !ok {
+				external[element] = len(qg.members[element])
+			}
+			external[element]--
+		}
+	}
+
+	var (
+		updated     = make([]int, 0, len(newMembers))
+		newExternal = len(newMembers) - 1
+	)
+
+	for variable := range newMembers {
+		bound := len(qg.variables[variable]) + newExternal
+		for element := range qg.elements[variable] {
+			if element != pivot {
+				bound += external[element]
+			}
+		}
+
+		degree := qg.degree[variable] + newExternal
+		if bound < degree {
+			degree = bound
+		}
+		if remaining-1 < degree {
+			degree = remaining - 1
+		}
+
+		qg.degree[variable] = degree
+		updated = append(updated, variable)
+	}
+
+	return updated
+}
+
+type degreeEntry struct {
+	node, degree int
+}
+
+// A degreeQueue is a priority queue of nodes by increasing degree, and then by increasing index.
+type degreeQueue []degreeEntry
+
+func (q degreeQueue) Len() int { return len(q) }
+
+func (q degreeQueue) Less(i, j int) bool {
+	if q[i].degree != q[j].degree {
+		return q[i].degree < q[j].degree
+	}
+
+	return q[i].node < q[j].node
+}
+
+func (q degreeQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *degreeQueue) Push(x interface{}) { *q = append(*q, x.(degreeEntry)) }
+
+func (q *degreeQueue) Pop() interface{} {
+	var (
+		old   = *q
+		entry = old[len(old)-1]
+	)
+
+	*q = old[:len(old)-1]
+	return entry
+}
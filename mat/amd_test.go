@@ -0,0 +1,141 @@
+package mat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestApproximateMinimumDegree(t *testing.T) {
+	t.Run("arrow matrix", func(t *testing.T) {
+		// The first row and column are full, thus eliminating it first fills the whole factor
+		var (
+			size = 8
+			m    = makeArrowMatrix(size)
+		)
+
+		ordering := ApproximateMinimumDegree(m)
+		assertPermutation(t, ordering.Permutation)
+
+		var (
+			natural = AnalyzeCholesky(m).NonZeroCount()
+			amd     = AnalyzeCholesky(MakeView(m, ordering.Permutation, ordering.Permutation)).NonZeroCount()
+		)
+
+		if natural != size*(size+1)/2 {
+			t.Errorf("Want %d non-zeros in the natural order, got %d", size*(size+1)/2, natural)
+		}
+		if amd != 2*size-1 {
+			t.Errorf("Want %d non-zeros in the AMD order, got %d", 2*size-1, amd)
+		}
+	})
+
+	t.Run("grid laplacian", func(t *testing.T) {
+		var (
+			m        = makeGridLaplacian(6)
+			ordering = ApproximateMinimumDegree(m)
+			natural  = AnalyzeCholesky(m).NonZeroCount()
+			permuted = MakeView(m, ordering.Permutation, ordering.Permutation)
+			amd      = AnalyzeCholesky(permuted).NonZeroCount()
+		)
+
+		assertPermutation(t, ordering.Permutation)
+		if amd >= natural {
+			t.Errorf("Want less fill than the natural order (%d non-zeros), got %d", natural, amd)
+		}
+		if got := countCholeskyNonZeros(permuted); got != amd {
+			t.Errorf("Want the predicted %d non-zeros in the factor, got %d", amd, got)
+		}
+	})
+}
+
+func TestAnalyzeCholesky(t *testing.T) {
+	t.Run("tridiagonal matrix", func(t *testing.T) {
+		m := MakeSparseWithData(4, 4, []float64{
+			2, -1, 0, 0,
+			-1, 2, -1, 0,
+			0, -1, 2, -1,
+			0, 0, -1, 2,
+		})
+
+		symbolic := AnalyzeCholesky(m)
+
+		assertIndices(t, symbolic.Parent, []int{1, 2, 3, -1})
+		assertIndices(t, symbolic.ColCounts, []int{2, 2, 2, 1})
+		if got := symbolic.NonZeroCount(); got != 7 {
+			t.Errorf("Want 7 non-zeros, got %d", got)
+		}
+	})
+
+	t.Run("predicts the fill-in", func(t *testing.T) {
+		m := makeGridLaplacian(4)
+		if got, want := AnalyzeCholesky(m).NonZeroCount(), countCholeskyNonZeros(m); got != want {
+			t.Errorf("Want %d non-zeros, got %d", want, got)
+		}
+	})
+}
+
+func makeArrowMatrix(size int) *SparseMat {
+	m := MakeSparse(size, size)
+	for i := 0; i < size; i++ {
+		m.SetValue(i, i, float64(size))
+		if i > 0 {
+			m.SetValue(0, i, 1.0)
+			m.SetValue(i, 0, 1.0)
+		}
+	}
+
+	return m
+}
+
+// makeGridLaplacian creates the matrix of the Laplacian in a grid of n x n nodes.
+func makeGridLaplacian(n int) *SparseMat {
+	m := MakeSparse(n*n, n*n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			node := i*n + j
+			m.SetValue(node, node, 4.0)
+
+			if j+1 < n {
+				m.SetValue(node, node+1, -1.0)
+				m.SetValue(node+1, node, -1.0)
+			}
+			if i+1 < n {
+				m.SetValue(node, node+n, -1.0)
+				m.SetValue(node+n, node, -1.0)
+			}
+		}
+	}
+
+	return m
+}
+
+// countCholeskyNonZeros computes the dense Cholesky factor of m and counts its non-zero values.
+func countCholeskyNonZeros(m ReadOnlyMatrix) int {
+	var (
+		size  = m.Rows()
+		l     = make([][]float64, size)
+		count = 0
+	)
+
+	for i := 0; i < size; i++ {
+		l[i] = make([]float64, size)
+		for j := 0; j <= i; j++ {
+			sum := m.Value(i, j)
+			for k := 0; k < j; k++ {
+				sum -= l[i][k] * l[j][k]
+			}
+
+			if i == j {
+				l[i][i] = math.Sqrt(sum)
+			} else {
+				l[i][j] = sum / l[j][j]
+			}
+
+			if math.Abs(l[i][j]) > 1e-12 {
+				count++
+			}
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,98 @@
+package mat
+
+/*
+Transpose returns a new matrix which is the transpose of the given one.
+
+The result is a SparseMat, unless the given matrix is dense, in which case it's a DenseMat.
+*/
+func Transpose(m ReadOnlyMatrix) MutableMatrix {
+	result := makeResultMatrix(m.Cols(), m.Rows(), isDense(m))
+
+	for i := 0; i < m.Rows(); i++ {
+		ForEachNonZeroInRow(m, i, func(j int, value float64) {
+			result.SetValue(j, i, value)
+		})
+	}
+
+	return result
+}
+
+/*
+Scale returns a new matrix with the values of the given one multiplied by a factor.
+
+The result is a SparseMat, unless the given matrix is dense, in which case it's a DenseMat.
+*/
+func Scale(factor float64, m ReadOnlyMatrix) MutableMatrix {
+	result := makeResultMatrix(m.Rows(), m.Cols(), isDense(m))
+
+	for i := 0; i < m.Rows(); i++ {
+		ForEachNonZeroInRow(m, i, func(j int, value float64) {
+			result.SetValue(i, j, factor*value)
+		})
+	}
+
+	return result
+}
+
+// Add returns a new matrix which is the sum of the two given matrices: a + b.
+func Add(a, b ReadOnlyMatrix) MutableMatrix {
+	return AddScaled(1.0, a, 1.0, b)
+}
+
+// Subtract returns a new matrix which is the subtraction of the two given matrices: a - b.
+func Subtract(a, b ReadOnlyMatrix) MutableMatrix {
+	return AddScaled(1.0, a, -1.0, b)
+}
+
+/*
+AddScaled returns a new matrix which is the linear combination of the two given matrices:
+alpha·a + beta·b.
+
+Both matrices need to have the same number of rows and columns. The result is a SparseMat when
+both matrices are sparse, and a DenseMat otherwise.
+*/
+func AddScaled(alpha float64, a ReadOnlyMatrix, beta float64, b ReadOnlyMatrix) MutableMatrix {
+	if a.Rows() != b.Rows() || a.Cols() != b.Cols() {
+		panic("Can't add matrices due to size mismatch")
+	}
+
+	var (
+		result = makeResultMatrix(a.Rows(), a.Cols(), isDense(a) || isDense(b))
+		rowSum map[int]float64
+	)
+
+	for i := 0; i < a.Rows(); i++ {
+		rowSum = make(map[int]float64)
+
+		ForEachNonZeroInRow(a, i, func(j int, value float64) {
+			rowSum[j] += alpha * value
+		})
+		ForEachNonZeroInRow(b, i, func(j int, value float64) {
+			rowSum[j] += beta * value
+		})
+
+		for j, sum := range rowSum {
+			result.SetValue(i, j, sum)
+		}
+	}
+
+	return result
+}
+
+// isDense returns true if the given matrix stores all of its values, including the zeroes.
+func isDense(m ReadOnlyMatrix) bool {
+	switch m.(type) {
+	case DenseMat, *DenseMat, SymDenseMat, *SymDenseMat:
+		return true
+	default:
+		return false
+	}
+}
+
+func makeResultMatrix(rows, cols int, dense bool) MutableMatrix {
+	if dense {
+		return MakeDense(rows, cols)
+	}
+
+	return MakeSparse(rows, cols)
+}
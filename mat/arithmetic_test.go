@@ -0,0 +1,90 @@
+package mat
+
+import "testing"
+
+func TestTranspose(t *testing.T) {
+	var (
+		data = []float64{1, 2, 3, 4, 0, 6}
+		want = []float64{1, 4, 2, 0, 3, 6}
+	)
+
+	t.Run("sparse matrix", func(t *testing.T) {
+		got := Transpose(MakeSparseWithData(2, 3, data))
+
+		if _, isSparse := got.(*SparseMat); !isSparse {
+			t.Errorf("Want a sparse matrix, got %T", got)
+		}
+		assertMatrixContainsData(t, got, want)
+	})
+
+	t.Run("dense matrix", func(t *testing.T) {
+		got := Transpose(MakeDenseWithData(2, 3, data))
+
+		if _, isDense := got.(*DenseMat); !isDense {
+			t.Errorf("Want a dense matrix, got %T", got)
+		}
+		assertMatrixContainsData(t, got, want)
+	})
+}
+
+func TestScale(t *testing.T) {
+	got := Scale(-2, MakeSparseWithData(2, 2, []float64{1, 0, 3, 4}))
+	assertMatrixContainsData(t, got, []float64{-2, 0, -6, -8})
+}
+
+func TestAddMatrices(t *testing.T) {
+	var (
+		aData = []float64{1, 0, 3, 4}
+		bData = []float64{2, 5, 0, 4}
+	)
+
+	t.Run("add", func(t *testing.T) {
+		got := Add(MakeSparseWithData(2, 2, aData), MakeSparseWithData(2, 2, bData))
+		assertMatrixContainsData(t, got, []float64{3, 5, 3, 8})
+	})
+
+	t.Run("subtract", func(t *testing.T) {
+		got := Subtract(MakeSparseWithData(2, 2, aData), MakeSparseWithData(2, 2, bData))
+		assertMatrixContainsData(t, got, []float64{-1, -5, 3, 0})
+
+		if indices := got.NonZeroIndicesAtRow(1); len(indices) != 1 {
+			t.Errorf("Expected zeroes not to be stored, got indices %v", indices)
+		}
+	})
+
+	t.Run("add scaled", func(t *testing.T) {
+		got := AddScaled(2, MakeSparseWithData(2, 2, aData), -0.5, MakeDenseWithData(2, 2, bData))
+
+		if _, isDense := got.(*DenseMat); !isDense {
+			t.Errorf("Want a dense matrix, got %T", got)
+		}
+		assertMatrixContainsData(t, got, []float64{1, -2.5, 6, 6})
+	})
+
+	t.Run("sizes must match", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected a panic due to size mismatch")
+			}
+		}()
+
+		Add(MakeSparse(2, 2), MakeSparse(2, 3))
+	})
+}
+
+func TestArithmeticKeepsSmallDenseValues(t *testing.T) {
+	m := MakeDenseWithData(1, 2, []float64{1e-11, 0})
+
+	if got := Transpose(m).Value(1, 0); got != 0 {
+		t.Errorf("Want 0, got %g", got)
+	}
+	if got := Transpose(m).Value(0, 0); got != 1e-11 {
+		t.Errorf("Want transposed value 1e-11, got %g", got)
+	}
+	if got := Scale(2, m).Value(0, 0); got != 2e-11 {
+		t.Errorf("Want scaled value 2e-11, got %g", got)
+	}
+	if got := Add(m, m).Value(0, 0); got != 2e-11 {
+		t.Errorf("Want added value 2e-11, got %g", got)
+	}
+}
@@ -0,0 +1,92 @@
+package mat
+
+/*
+AddSubMatrix adds the values of a local square matrix, like the stiffness matrix of an element,
+into the global matrix. The dofs slice maps each local row and column to the global one, so the
+value at (i, j) of the local matrix is added to the global matrix at (dofs[i], dofs[j]).
+
+Degrees of freedom set to -1 are skipped, which is useful to leave out constrained degrees of
+freedom. For global matrices which are symmetric by construction, like SymSparseMat or
+SkylineMat, only the values mapped to the upper triangle are added, as adding a value also adds it
+to its symmetric position, where the mirrored local value would be added again.
+*/
+func AddSubMatrix(global MutableMatrix, dofs []int, local ReadOnlyMatrix) {
+	AddScaledRectSubMatrix(global, dofs, dofs, 1.0, local)
+}
+
+// AddScaledSubMatrix adds the values of the local matrix, scaled by a factor, into the global one.
+// See AddSubMatrix for the details.
+func AddScaledSubMatrix(global MutableMatrix, dofs []int, factor float64, local ReadOnlyMatrix) {
+	AddScaledRectSubMatrix(global, dofs, dofs, factor, local)
+}
+
+/*
+AddRectSubMatrix adds the values of a local rectangular matrix into the global matrix, where the
+local row i maps to the global row rowDofs[i] and the local column j to the global column
+colDofs[j]. Degrees of freedom set to -1 are skipped.
+
+For global matrices which are symmetric by construction, every value is added, which also adds it
+to its symmetric position. Thus, only one of the two coupling blocks, like A_ab or A_ba, should be
+added. When the row and column degrees of freedom are the same, it works like AddSubMatrix.
+*/
+func AddRectSubMatrix(global MutableMatrix, rowDofs, colDofs []int, local ReadOnlyMatrix) {
+	AddScaledRectSubMatrix(global, rowDofs, colDofs, 1.0, local)
+}
+
+// AddScaledRectSubMatrix adds the values of the local rectangular matrix, scaled by a factor,
+// into the global one. See AddRectSubMatrix for the details.
+func AddScaledRectSubMatrix(
+	global MutableMatrix,
+	rowDofs, colDofs []int,
+	factor float64,
+	local ReadOnlyMatrix,
+) {
+	if local.Rows() != len(rowDofs) || local.Cols() != len(colDofs) {
+		panic("Can't add sub-matrix due to size mismatch")
+	}
+
+	assertDofsInRange(rowDofs, global.Rows())
+	assertDofsInRange(colDofs, global.Cols())
+
+	// The mirrored values are only in the local matrix when it maps to the same global rows and
+	// columns, so they're only skipped in that case
+	_, isSymmetric := global.(symmetricMatrix)
+	upperOnly := isSymmetric && sameDofs(rowDofs, colDofs)
+
+	for i, row := range rowDofs {
+		if row < 0 {
+			continue
+		}
+
+		ForEachNonZeroInRow(local, i, func(j int, value float64) {
+			col := colDofs[j]
+			if col < 0 || (upperOnly && col < row) {
+				return
+			}
+
+			global.AddToValue(row, col, factor*value)
+		})
+	}
+}
+
+func assertDofsInRange(dofs []int, size int) {
+	for _, dof := range dofs {
+		if dof < -1 || dof >= size {
+			panic("Can't add sub-matrix with degrees of freedom out of the matrix bounds")
+		}
+	}
+}
+
+func sameDofs(rowDofs, colDofs []int) bool {
+	if len(rowDofs) != len(colDofs) {
+		return false
+	}
+
+	for i := range rowDofs {
+		if rowDofs[i] != colDofs[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,96 @@
+package mat
+
+import "testing"
+
+func TestAddSubMatrix(t *testing.T) {
+	local := MakeDenseWithData(2, 2, []float64{
+		1, -1,
+		-1, 1,
+	})
+
+	t.Run("scatters the values", func(t *testing.T) {
+		global := MakeSparse(3, 3)
+
+		AddSubMatrix(global, []int{0, 1}, local)
+		AddSubMatrix(global, []int{1, 2}, local)
+
+		assertMatrixContainsData(t, global, []float64{
+			1, -1, 0,
+			-1, 2, -1,
+			0, -1, 1,
+		})
+	})
+
+	t.Run("skips constrained dofs and scales", func(t *testing.T) {
+		global := MakeSparse(3, 3)
+
+		AddScaledSubMatrix(global, []int{2, -1}, 3.0, local)
+
+		assertMatrixContainsData(t, global, []float64{
+			0, 0, 0,
+			0, 0, 0,
+			0, 0, 3,
+		})
+	})
+
+	t.Run("symmetric targets only get the upper triangle", func(t *testing.T) {
+		for _, global := range []MutableMatrix{MakeSymSparse(3), MakeSymDense(3), MakeSkyline(3)} {
+			AddSubMatrix(global, []int{2, 0}, local)
+
+			assertMatrixContainsData(t, global, []float64{
+				1, 0, -1,
+				0, 0, 0,
+				-1, 0, 1,
+			})
+		}
+	})
+
+	t.Run("rectangular sub-matrix", func(t *testing.T) {
+		var (
+			global = MakeDense(2, 3)
+			block  = MakeDenseWithData(1, 2, []float64{5, 6})
+		)
+
+		AddRectSubMatrix(global, []int{1}, []int{2, 0}, block)
+		AddScaledRectSubMatrix(global, []int{0}, []int{-1, 1}, 2.0, block)
+
+		assertMatrixContainsData(t, global, []float64{
+			0, 12, 0,
+			6, 0, 5,
+		})
+	})
+
+	t.Run("rectangular sub-matrix into symmetric targets", func(t *testing.T) {
+		block := MakeDenseWithData(1, 2, []float64{5, 6})
+
+		for _, global := range []MutableMatrix{MakeSymSparse(3), MakeSymDense(3), MakeSkyline(3)} {
+			AddRectSubMatrix(global, []int{2}, []int{0, 1}, block)
+
+			assertMatrixContainsData(t, global, []float64{
+				0, 0, 5,
+				0, 0, 6,
+				5, 6, 0,
+			})
+		}
+	})
+
+	t.Run("size mismatch", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected a panic")
+			}
+		}()
+
+		AddSubMatrix(MakeSparse(3, 3), []int{0, 1, 2}, local)
+	})
+
+	t.Run("dofs out of bounds", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected a panic")
+			}
+		}()
+
+		AddSubMatrix(MakeSparse(3, 3), []int{0, 3}, local)
+	})
+}
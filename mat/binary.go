@@ -0,0 +1,224 @@
+package mat
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"math"
+	"sort"
+)
+
+/*
+The binary format of the matrices is made of the following little-endian encoded fields:
+
+	- Magic (4 bytes): "INKD" for dense matrices and "INKS" for sparse ones
+	- Version (1 byte): the version of the format, currently 1
+	- Rows (8 bytes) and columns (8 bytes)
+	- The matrix values:
+		- Dense matrices: the IEEE 754 bits (8 bytes) of every value, row after row
+		- Sparse matrices: the number of non-zero values (8 bytes), followed by the row (4 bytes),
+		  column (4 bytes) and IEEE 754 bits (8 bytes) of each of them, sorted by row and column
+	- Checksum (4 bytes): the CRC-32 (IEEE) checksum of all the preceding bytes
+*/
+const (
+	denseBinaryMagic  = "INKD"
+	sparseBinaryMagic = "INKS"
+	binaryVersion     = 1
+	binaryHeaderSize  = 4 + 1 + 8 + 8
+)
+
+var (
+	// ErrBinaryFormat is returned when the binary data doesn't contain the expected matrix.
+	ErrBinaryFormat = errors.New("mat: invalid binary data")
+	// ErrBinaryVersion is returned when the binary data uses an unknown version of the format.
+	ErrBinaryVersion = errors.New("mat: unsupported binary format version")
+	// ErrBinaryChecksum is returned when the binary data is corrupted.
+	ErrBinaryChecksum = errors.New("mat: binary data checksum mismatch")
+)
+
+/*
+MarshalBinary encodes the matrix into a versioned binary format with a checksum.
+
+Matrices with rows but no columns can't be encoded, as there wouldn't be any values to back the
+number of rows when decoding them.
+*/
+func (m DenseMat) MarshalBinary() ([]byte, error) {
+	if m.rows > 0 && m.cols == 0 {
+		return nil, errors.New("mat: dense matrix with rows and no columns")
+	}
+
+	data := makeBinaryHeader(denseBinaryMagic, m.rows, m.cols, 8*m.rows*m.cols)
+
+	for _, row := range m.data {
+		for _, val := range row {
+			data = appendUint64(data, math.Float64bits(val))
+		}
+	}
+
+	return appendUint32(data, crc32.ChecksumIEEE(data)), nil
+}
+
+// UnmarshalBinary decodes a matrix encoded with MarshalBinary, overwriting this matrix.
+func (m *DenseMat) UnmarshalBinary(data []byte) error {
+	rows, cols, body, err := readBinaryHeader(denseBinaryMagic, data)
+	if err != nil {
+		return err
+	}
+
+	if !binarySizeFits(rows, cols) || uint64(len(body))%8 != 0 {
+		return ErrBinaryFormat
+	}
+
+	/*
+		The number of values is compared dividing, as multiplying the sizes could overflow. Rows
+		without columns are rejected, as there are no values to back them and creating the matrix
+		allocates memory for each of them.
+	*/
+	values := uint64(len(body)) / 8
+	if rows == 0 && values != 0 {
+		return ErrBinaryFormat
+	}
+	if rows != 0 && (cols == 0 || values%rows != 0 || values/rows != cols) {
+		return ErrBinaryFormat
+	}
+
+	*m = *MakeDense(int(rows), int(cols))
+	for i := 0; i < m.rows; i++ {
+		for j := 0; j < m.cols; j++ {
+			m.data[i][j] = math.Float64frombits(binary.LittleEndian.Uint64(body))
+			body = body[8:]
+		}
+	}
+
+	return nil
+}
+
+/*
+MarshalBinary encodes the matrix into a versioned binary format with a checksum. Only the
+non-zero values are stored.
+*/
+func (m SparseMat) MarshalBinary() ([]byte, error) {
+	if uint64(m.rows) > math.MaxUint32 || uint64(m.cols) > math.MaxUint32 {
+		return nil, errors.New("mat: matrix too large for the binary format")
+	}
+
+	var (
+		rowIndices = make([]int, 0, len(m.data))
+		nnz        = 0
+	)
+
+	for row, dataRow := range m.data {
+		rowIndices = append(rowIndices, row)
+		nnz += len(dataRow)
+	}
+	sort.Ints(rowIndices)
+
+	data := makeBinaryHeader(sparseBinaryMagic, m.rows, m.cols, 8+16*nnz)
+	data = appendUint64(data, uint64(nnz))
+
+	for _, row := range rowIndices {
+		cols := m.NonZeroIndicesAtRow(row)
+		sort.Ints(cols)
+
+		for _, col := range cols {
+			data = appendUint32(data, uint32(row))
+			data = appendUint32(data, uint32(col))
+			data = appendUint64(data, math.Float64bits(m.data[row][col]))
+		}
+	}
+
+	return appendUint32(data, crc32.ChecksumIEEE(data)), nil
+}
+
+// UnmarshalBinary decodes a matrix encoded with MarshalBinary, overwriting this matrix.
+func (m *SparseMat) UnmarshalBinary(data []byte) error {
+	rows, cols, body, err := readBinaryHeader(sparseBinaryMagic, data)
+	if err != nil {
+		return err
+	}
+
+	if !binarySizeFits(rows, cols) || len(body) < 8 {
+		return ErrBinaryFormat
+	}
+
+	nnz := binary.LittleEndian.Uint64(body)
+	body = body[8:]
+
+	if nnz > uint64(len(body))/16 || uint64(len(body)) != 16*nnz {
+		return ErrBinaryFormat
+	}
+
+	*m = *MakeSparse(int(rows), int(cols))
+	for i := uint64(0); i < nnz; i++ {
+		var (
+			row   = uint64(binary.LittleEndian.Uint32(body))
+			col   = uint64(binary.LittleEndian.Uint32(body[4:]))
+			value = math.Float64frombits(binary.LittleEndian.Uint64(body[8:]))
+		)
+
+		if row >= rows || col >= cols {
+			return ErrBinaryFormat
+		}
+
+		m.setValueToAdd(int(row), int(col), value)
+		body = body[16:]
+	}
+
+	return nil
+}
+
+// binarySizeFits returns whether the decoded sizes of a matrix fit in an int in every platform.
+func binarySizeFits(rows, cols uint64) bool {
+	return rows <= math.MaxInt32 && cols <= math.MaxInt32
+}
+
+func makeBinaryHeader(magic string, rows, cols, bodySize int) []byte {
+	data := make([]byte, 0, binaryHeaderSize+bodySize+4)
+	data = append(data, magic...)
+	data = append(data, binaryVersion)
+	data = appendUint64(data, uint64(rows))
+	data = appendUint64(data, uint64(cols))
+
+	return data
+}
+
+/*
+readBinaryHeader checks the magic, checksum and version of the binary data, and returns the rows
+and columns of the matrix, together with the bytes after the header (without the checksum).
+*/
+func readBinaryHeader(magic string, data []byte) (rows, cols uint64, body []byte, err error) {
+	if len(data) < binaryHeaderSize+4 || string(data[:len(magic)]) != magic {
+		return 0, 0, nil, ErrBinaryFormat
+	}
+
+	var (
+		payload  = data[:len(data)-4]
+		checksum = binary.LittleEndian.Uint32(data[len(data)-4:])
+	)
+
+	if crc32.ChecksumIEEE(payload) != checksum {
+		return 0, 0, nil, ErrBinaryChecksum
+	}
+	if payload[len(magic)] != binaryVersion {
+		return 0, 0, nil, ErrBinaryVersion
+	}
+
+	rows = binary.LittleEndian.Uint64(payload[len(magic)+1:])
+	cols = binary.LittleEndian.Uint64(payload[len(magic)+9:])
+
+	return rows, cols, payload[binaryHeaderSize:], nil
+}
+
+func appendUint64(data []byte, value uint64) []byte {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], value)
+
+	return append(data, buf[:]...)
+}
+
+func appendUint32(data []byte, value uint32) []byte {
+	var buf [4]byte
+	binary.LittleEndian.PutUint32(buf[:], value)
+
+	return append(data, buf[:]...)
+}
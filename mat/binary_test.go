@@ -0,0 +1,121 @@
+package mat
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"hash/crc32"
+	"testing"
+)
+
+func TestBinaryEncoding(t *testing.T) {
+	data := []float64{1, 0, -2.5, 0, 0, 3e10}
+
+	t.Run("dense matrix round trip", func(t *testing.T) {
+		encoded, err := MakeDenseWithData(2, 3, data).MarshalBinary()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		var decoded DenseMat
+		if err := decoded.UnmarshalBinary(encoded); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		assertMatrixContainsData(t, decoded, data)
+	})
+
+	t.Run("sparse matrix round trip stores only non-zeroes", func(t *testing.T) {
+		encoded, err := MakeSparseWithData(2, 3, data).MarshalBinary()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if want := binaryHeaderSize + 8 + 3*16 + 4; len(encoded) != want {
+			t.Errorf("Want %d bytes, got %d", want, len(encoded))
+		}
+
+		var decoded SparseMat
+		if err := decoded.UnmarshalBinary(encoded); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		assertMatrixContainsData(t, decoded, data)
+	})
+
+	t.Run("works with gob", func(t *testing.T) {
+		var (
+			buf     bytes.Buffer
+			decoded *SparseMat
+		)
+
+		if err := gob.NewEncoder(&buf).Encode(MakeSparseWithData(2, 3, data)); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		assertMatrixContainsData(t, decoded, data)
+	})
+
+	t.Run("detects corrupted data", func(t *testing.T) {
+		encoded, _ := MakeDenseWithData(2, 3, data).MarshalBinary()
+		encoded[binaryHeaderSize+3] ^= 0xff
+
+		var decoded DenseMat
+		if err := decoded.UnmarshalBinary(encoded); !errors.Is(err, ErrBinaryChecksum) {
+			t.Errorf("Want checksum error, got %v", err)
+		}
+	})
+
+	t.Run("detects the wrong matrix type", func(t *testing.T) {
+		encoded, _ := MakeDenseWithData(2, 3, data).MarshalBinary()
+
+		var decoded SparseMat
+		if err := decoded.UnmarshalBinary(encoded); !errors.Is(err, ErrBinaryFormat) {
+			t.Errorf("Want format error, got %v", err)
+		}
+	})
+
+	t.Run("rejects sizes which don't match the data", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			magic      string
+			rows, cols uint64
+			body       []byte
+		}{
+			// 8 * rows * cols overflows to the body size
+			{"dense overflow", denseBinaryMagic, 1 << 31, 1 << 31, nil},
+			{"dense too large", denseBinaryMagic, 1 << 40, 1, make([]byte, 8)},
+			{"dense partial row", denseBinaryMagic, 2, 1, make([]byte, 24)},
+			{"dense rows without columns", denseBinaryMagic, 1 << 24, 0, nil},
+			{"sparse too large", sparseBinaryMagic, 1 << 40, 1 << 40, make([]byte, 8)},
+		}
+
+		for _, test := range tests {
+			encoded := makeBinaryHeader(test.magic, 0, 0, len(test.body))[:len(test.magic)+1]
+			encoded = appendUint64(encoded, test.rows)
+			encoded = appendUint64(encoded, test.cols)
+			encoded = append(encoded, test.body...)
+			encoded = appendUint32(encoded, crc32.ChecksumIEEE(encoded))
+
+			var err error
+			if test.magic == denseBinaryMagic {
+				err = new(DenseMat).UnmarshalBinary(encoded)
+			} else {
+				err = new(SparseMat).UnmarshalBinary(encoded)
+			}
+
+			if !errors.Is(err, ErrBinaryFormat) {
+				t.Errorf("%s: want format error, got %v", test.name, err)
+			}
+		}
+	})
+
+	t.Run("dense matrix with rows and no columns can't be encoded", func(t *testing.T) {
+		if _, err := MakeDense(3, 0).MarshalBinary(); err == nil {
+			t.Error("Expected an error")
+		}
+	})
+}
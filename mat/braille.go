@@ -0,0 +1,71 @@
+package mat
+
+import "strings"
+
+// brailleDots has the bit of each dot in a braille character, indexed by [row][col].
+var brailleDots = [4][2]rune{
+	{0x01, 0x08},
+	{0x02, 0x10},
+	{0x04, 0x20},
+	{0x40, 0x80},
+}
+
+const brailleBlank = '⠀'
+
+/*
+BrailleSpy returns a spy plot of the matrix made of Unicode braille characters, to quickly view
+the sparsity pattern of large matrices in a terminal.
+
+Each braille character has two columns and four rows of dots, where each dot represents a matrix
+entry. Matrices with more than 2 * maxWidth columns are downsampled, so that each dot represents
+a square block of entries, which is drawn if any of them is non-zero. Only the non-zero indices of
+each row are visited.
+
+The returned string has a line per row of characters, each ending in a new line.
+*/
+func BrailleSpy(m ReadOnlyMatrix, maxWidth int) string {
+	if maxWidth < 1 {
+		panic("Can't draw a braille spy plot narrower than one character")
+	}
+
+	var (
+		rows, cols = m.Rows(), m.Cols()
+		blockSize  = 1
+	)
+
+	if cols > 2*maxWidth {
+		blockSize = (cols + 2*maxWidth - 1) / (2 * maxWidth)
+	}
+
+	var (
+		dotCols = (cols + blockSize - 1) / blockSize
+		dotRows = (rows + blockSize - 1) / blockSize
+		width   = (dotCols + 1) / 2
+		height  = (dotRows + 3) / 4
+		chars   = make([][]rune, height)
+	)
+
+	for i := range chars {
+		chars[i] = make([]rune, width)
+		for j := range chars[i] {
+			chars[i][j] = brailleBlank
+		}
+	}
+
+	for row := 0; row < rows; row++ {
+		dotRow := row / blockSize
+
+		for _, col := range m.NonZeroIndicesAtRow(row) {
+			dotCol := col / blockSize
+			chars[dotRow/4][dotCol/2] |= brailleDots[dotRow%4][dotCol%2]
+		}
+	}
+
+	var builder strings.Builder
+	for _, line := range chars {
+		builder.WriteString(string(line))
+		builder.WriteByte('\n')
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,138 @@
+package mat
+
+import (
+	"math"
+
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+// A Factorization of a square matrix A can be used to solve systems with A and its transpose.
+type Factorization interface {
+	// Size returns the number of rows (and columns) of the factorized matrix.
+	Size() int
+	// Solve solves the system A x = b.
+	Solve(b vec.ReadOnlyVector) vec.ReadOnlyVector
+	// SolveTransposed solves the system Aᵀ x = b.
+	SolveTransposed(b vec.ReadOnlyVector) vec.ReadOnlyVector
+}
+
+// condEstimateMaxIter is the maximum number of iterations of the inverse 1-norm estimation.
+const condEstimateMaxIter = 5
+
+/*
+EstimateCondOne estimates the 1-norm condition number of the matrix, ‖A‖₁ ‖A⁻¹‖₁, using its
+factorization to estimate the norm of the inverse. See EstimateInverseNormOne.
+
+Large condition numbers mean that the matrix is close to singular, and that the solution of the
+systems of equations with this matrix may lose up to log10 of the condition number significant
+digits. The condition number of a singular matrix is infinite.
+*/
+func EstimateCondOne(m ReadOnlyMatrix, f Factorization) float64 {
+	if m.Rows() != f.Size() || m.Cols() != f.Size() {
+		panic("Can't estimate the condition number due to size mismatch")
+	}
+
+	return NormOne(m) * EstimateInverseNormOne(f)
+}
+
+/*
+EstimateInverseNormOne estimates the 1-norm of the inverse of the factorized matrix without
+computing the inverse, using Hager's method with Higham's refinements (as in LAPACK's xLACON).
+
+The estimate is a lower bound of the actual norm, which is usually exact or within a factor of
+three. It requires a few solves with the factorization and its transpose, so its cost is small
+compared to that of the factorization.
+*/
+func EstimateInverseNormOne(f Factorization) float64 {
+	n := f.Size()
+	if n == 0 {
+		return 0.0
+	}
+
+	var (
+		x        = vec.Make(n)
+		y        vec.ReadOnlyVector
+		z        vec.ReadOnlyVector
+		estimate float64
+	)
+
+	for i := 0; i < n; i++ {
+		x.SetValue(i, 1.0/float64(n))
+	}
+
+	y = f.Solve(x)
+	estimate = vectorNormOne(y)
+	if n == 1 {
+		return estimate
+	}
+
+	z = f.SolveTransposed(signs(y))
+
+	for iter := 0; iter < condEstimateMaxIter; iter++ {
+		var (
+			maxIndex = 0
+			zx       = 0.0
+		)
+
+		for i := 0; i < n; i++ {
+			if math.Abs(z.Value(i)) > math.Abs(z.Value(maxIndex)) {
+				maxIndex = i
+			}
+			zx += z.Value(i) * x.Value(i)
+		}
+
+		// The gradient doesn't show a better unit vector to try
+		if iter > 0 && math.Abs(z.Value(maxIndex)) <= zx {
+			break
+		}
+
+		x = vec.Make(n)
+		x.SetValue(maxIndex, 1.0)
+
+		y = f.Solve(x)
+		newEstimate := vectorNormOne(y)
+		if newEstimate <= estimate {
+			break
+		}
+
+		estimate = newEstimate
+		z = f.SolveTransposed(signs(y))
+	}
+
+	// Higham's alternative estimate, which covers the cases where the gradient iterations fail
+	for i := 0; i < n; i++ {
+		value := 1.0 + float64(i)/float64(n-1)
+		if i%2 == 1 {
+			value = -value
+		}
+
+		x.SetValue(i, value)
+	}
+
+	alternative := 2.0 * vectorNormOne(f.Solve(x)) / (3.0 * float64(n))
+
+	return math.Max(estimate, alternative)
+}
+
+func vectorNormOne(v vec.ReadOnlyVector) float64 {
+	norm := 0.0
+	for i := 0; i < v.Length(); i++ {
+		norm += math.Abs(v.Value(i))
+	}
+
+	return norm
+}
+
+// signs returns a vector with the sign of each value in v, where zero is considered positive.
+func signs(v vec.ReadOnlyVector) vec.ReadOnlyVector {
+	result := vec.Make(v.Length())
+	for i := 0; i < v.Length(); i++ {
+		if v.Value(i) >= 0.0 {
+			result.SetValue(i, 1.0)
+		} else {
+			result.SetValue(i, -1.0)
+		}
+	}
+
+	return result
+}
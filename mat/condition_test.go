@@ -0,0 +1,80 @@
+package mat
+
+import (
+	"math"
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkmath/nums"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+func TestEstimateCondOne(t *testing.T) {
+	t.Run("symmetric positive definite matrix", func(t *testing.T) {
+		m := MakeSkylineFromMatrix(MakeSparseWithData(3, 3, []float64{
+			4, -1, 0,
+			-1, 4, -1,
+			0, -1, 4,
+		}))
+		original := m.Clone()
+
+		ldlt, err := m.FactorizeLDLT()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		// The 1-norm of the inverse is the largest absolute column sum of its columns
+		want := 0.0
+		for col := 0; col < 3; col++ {
+			unit := vec.Make(3)
+			unit.SetValue(col, 1.0)
+			want = math.Max(want, vectorNormOne(ldlt.Solve(unit)))
+		}
+
+		if got := EstimateInverseNormOne(ldlt); !nums.FloatsEqual(got, want) {
+			t.Errorf("Want inverse norm %f, got %f", want, got)
+		}
+		if got := EstimateCondOne(original, ldlt); !nums.FloatsEqual(got, 6*want) {
+			t.Errorf("Want condition number %f, got %f", 6*want, got)
+		}
+	})
+
+	t.Run("non symmetric matrix", func(t *testing.T) {
+		// A = [[1, 100], [0, 1]] has the inverse [[1, -100], [0, 1]]
+		var (
+			m = MakeSparseWithData(2, 2, []float64{1, 100, 0, 1})
+			f = upperUnitFactorization{100}
+		)
+
+		if got := EstimateInverseNormOne(f); got != 101 {
+			t.Errorf("Want inverse norm 101, got %f", got)
+		}
+		if got := EstimateCondOne(m, f); got != 101*101 {
+			t.Errorf("Want condition number %d, got %f", 101*101, got)
+		}
+	})
+
+	t.Run("size mismatch", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected a panic")
+			}
+		}()
+
+		EstimateCondOne(MakeIdentity(3), upperUnitFactorization{1})
+	})
+}
+
+// upperUnitFactorization factorizes the matrix [[1, a], [0, 1]].
+type upperUnitFactorization struct {
+	a float64
+}
+
+func (f upperUnitFactorization) Size() int { return 2 }
+
+func (f upperUnitFactorization) Solve(b vec.ReadOnlyVector) vec.ReadOnlyVector {
+	return vec.MakeWithValues([]float64{b.Value(0) - f.a*b.Value(1), b.Value(1)})
+}
+
+func (f upperUnitFactorization) SolveTransposed(b vec.ReadOnlyVector) vec.ReadOnlyVector {
+	return vec.MakeWithValues([]float64{b.Value(0), b.Value(1) - f.a*b.Value(0)})
+}
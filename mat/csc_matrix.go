@@ -0,0 +1,257 @@
+package mat
+
+import (
+	"sort"
+
+	"github.com/angelsolaorbaiceta/inkmath/nums"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+/*
+A CSCMat is an immutable sparse matrix stored in Compressed Sparse Column format.
+
+The non-zero values are stored contiguously, column after column, with their row indices
+sorted in ascending order. The values for the column j are stored in the range
+[colPtr[j], colPtr[j+1]) of the rowIdx and values slices.
+
+CSC matrices are the natural layout for column-oriented operations, like the ones found in
+sparse direct factorizations, or for multiplying the transpose of a matrix times a vector.
+*/
+type CSCMat struct {
+	rows, cols int
+	colPtr     []int
+	rowIdx     []int
+	values     []float64
+}
+
+/*
+MakeCSC creates a new CSC matrix with the non-zero values of the given matrix.
+
+The passed in matrix can be any ReadOnlyMatrix, but it's usually a SparseMat whose assembly
+is finished.
+*/
+func MakeCSC(m ReadOnlyMatrix) *CSCMat {
+	var (
+		rows, cols = m.Rows(), m.Cols()
+		colCounts  = make([]int, cols)
+		indices    = make([][]int, rows)
+		val        float64
+	)
+
+	for row := 0; row < rows; row++ {
+		indices[row] = m.NonZeroIndicesAtRow(row)
+		for _, col := range indices[row] {
+			colCounts[col]++
+		}
+	}
+
+	var (
+		colPtr = make([]int, cols+1)
+		next   = make([]int, cols)
+	)
+
+	for col := 0; col < cols; col++ {
+		colPtr[col+1] = colPtr[col] + colCounts[col]
+		next[col] = colPtr[col]
+	}
+
+	var (
+		rowIdx = make([]int, colPtr[cols])
+		values = make([]float64, colPtr[cols])
+	)
+
+	// Rows are visited in ascending order, so the row indices in each column end up sorted.
+	for row := 0; row < rows; row++ {
+		for _, col := range indices[row] {
+			rowIdx[next[col]] = row
+			values[next[col]] = m.Value(row, col)
+			next[col]++
+		}
+	}
+
+	// Drop the values which happen to be stored as zeroes.
+	var (
+		nnz   = 0
+		start = 0
+	)
+	for col := 0; col < cols; col++ {
+		for i := start; i < colPtr[col+1]; i++ {
+			if val = values[i]; !nums.IsCloseToZero(val) {
+				rowIdx[nnz] = rowIdx[i]
+				values[nnz] = val
+				nnz++
+			}
+		}
+
+		start = colPtr[col+1]
+		colPtr[col+1] = nnz
+	}
+
+	return &CSCMat{rows, cols, colPtr, rowIdx[:nnz], values[:nnz]}
+}
+
+// Rows returns the number of rows in the matrix.
+func (m CSCMat) Rows() int { return m.rows }
+
+// Cols returns the number of columns in the matrix.
+func (m CSCMat) Cols() int { return m.cols }
+
+// NonZeroCount returns the number of stored (non-zero) values in the matrix.
+func (m CSCMat) NonZeroCount() int { return len(m.values) }
+
+// Value returns the value at a given row and column.
+func (m CSCMat) Value(row, col int) float64 {
+	if i, found := m.indexOf(row, col); found {
+		return m.values[i]
+	}
+
+	return 0.0
+}
+
+func (m CSCMat) indexOf(row, col int) (int, bool) {
+	var (
+		start = m.colPtr[col]
+		end   = m.colPtr[col+1]
+		i     = start + sort.SearchInts(m.rowIdx[start:end], row)
+	)
+
+	return i, i < end && m.rowIdx[i] == row
+}
+
+/*
+NonZeroIndicesAtRow returns a slice with all non-zero elements indices for the given row.
+The indices are sorted in ascending order.
+
+Rows aren't stored contiguously in a CSC matrix, so this method needs to look for the row in
+every column. Prefer column oriented operations when working with CSC matrices.
+*/
+func (m CSCMat) NonZeroIndicesAtRow(row int) []int {
+	indices := make([]int, 0)
+
+	for col := 0; col < m.cols; col++ {
+		if _, found := m.indexOf(row, col); found {
+			indices = append(indices, col)
+		}
+	}
+
+	return indices
+}
+
+/*
+NonZeroIndicesAtCol returns a slice with all non-zero elements indices for the given column.
+The indices are sorted in ascending order.
+*/
+func (m CSCMat) NonZeroIndicesAtCol(col int) []int {
+	var (
+		start   = m.colPtr[col]
+		end     = m.colPtr[col+1]
+		indices = make([]int, end-start)
+	)
+
+	copy(indices, m.rowIdx[start:end])
+
+	return indices
+}
+
+/*
+ForEachInCol calls the given function with the row index and value of every non-zero
+element in the given column, in ascending row order.
+*/
+func (m CSCMat) ForEachInCol(col int, fn func(row int, value float64)) {
+	for i := m.colPtr[col]; i < m.colPtr[col+1]; i++ {
+		fn(m.rowIdx[i], m.values[i])
+	}
+}
+
+// TimesVector multiplies this matrix and a vector.
+func (m CSCMat) TimesVector(v vec.ReadOnlyVector) vec.ReadOnlyVector {
+	if m.cols != v.Length() {
+		panic("Can't multiply matrix and vector due to size mismatch")
+	}
+
+	var (
+		result = vec.Make(m.rows)
+		val    float64
+	)
+
+	for col := 0; col < m.cols; col++ {
+		if val = v.Value(col); val == 0.0 {
+			continue
+		}
+
+		for i := m.colPtr[col]; i < m.colPtr[col+1]; i++ {
+			result.SetValue(m.rowIdx[i], result.Value(m.rowIdx[i])+m.values[i]*val)
+		}
+	}
+
+	return result
+}
+
+// TransposeTimesVector multiplies the transpose of this matrix and a vector.
+func (m CSCMat) TransposeTimesVector(v vec.ReadOnlyVector) vec.ReadOnlyVector {
+	if m.rows != v.Length() {
+		panic("Can't multiply transposed matrix and vector due to size mismatch")
+	}
+
+	var (
+		result = vec.Make(m.cols)
+		sum    float64
+	)
+
+	for col := 0; col < m.cols; col++ {
+		sum = 0.0
+		for i := m.colPtr[col]; i < m.colPtr[col+1]; i++ {
+			sum += m.values[i] * v.Value(m.rowIdx[i])
+		}
+
+		result.SetValue(col, sum)
+	}
+
+	return result
+}
+
+/*
+TimesMatrix multiplies this matrix times other.
+
+The result is a SparseMat whose values are computed accumulating, for each non-zero value in
+the other matrix, the scaled columns of this matrix.
+*/
+func (m CSCMat) TimesMatrix(other ReadOnlyMatrix) ReadOnlyMatrix {
+	if m.cols != other.Rows() {
+		panic("Can't multiply matrices due to size mismatch")
+	}
+
+	var (
+		result = MakeSparse(m.rows, other.Cols())
+		val    float64
+	)
+
+	for k := 0; k < other.Rows(); k++ {
+		for _, col := range other.NonZeroIndicesAtRow(k) {
+			val = other.Value(k, col)
+
+			for i := m.colPtr[k]; i < m.colPtr[k+1]; i++ {
+				result.AddToValue(m.rowIdx[i], col, m.values[i]*val)
+			}
+		}
+	}
+
+	return result
+}
+
+// RowTimesVector returns the result of multiplying the row at the given index times the given vector.
+func (m CSCMat) RowTimesVector(row int, v vec.ReadOnlyVector) float64 {
+	if m.cols != v.Length() {
+		panic("Can't multiply matrix row with vector due to size mismatch")
+	}
+
+	result := 0.0
+
+	for col := 0; col < m.cols; col++ {
+		if i, found := m.indexOf(row, col); found {
+			result += m.values[i] * v.Value(col)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,79 @@
+package mat
+
+import (
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+func TestCSCMatrix(t *testing.T) {
+	var (
+		data = []float64{
+			4, 0, 1, 0,
+			0, 0, 0, 0,
+			-2, 3, 0, 5,
+		}
+		csc = MakeCSC(MakeSparseWithData(3, 4, data))
+	)
+
+	t.Run("contains the same data as the original matrix", func(t *testing.T) {
+		assertMatrixContainsData(t, csc, data)
+	})
+
+	t.Run("stores only the non-zero values", func(t *testing.T) {
+		if got := csc.NonZeroCount(); got != 5 {
+			t.Errorf("Want 5 non-zero values, got %d", got)
+		}
+	})
+
+	t.Run("non-zero indices at row and column", func(t *testing.T) {
+		assertIndices(t, csc.NonZeroIndicesAtRow(2), []int{0, 1, 3})
+		assertIndices(t, csc.NonZeroIndicesAtCol(0), []int{0, 2})
+		assertIndices(t, csc.NonZeroIndicesAtCol(2), []int{0})
+	})
+
+	t.Run("iterates the column values", func(t *testing.T) {
+		sum := 0.0
+		csc.ForEachInCol(0, func(row int, value float64) {
+			sum += float64(row+1) * value
+		})
+
+		if sum != -2 {
+			t.Errorf("Want -2, got %f", sum)
+		}
+	})
+
+	t.Run("times vector", func(t *testing.T) {
+		var (
+			v    = vec.MakeWithValues([]float64{1, 2, 3, 4})
+			want = []float64{7, 0, 24}
+		)
+
+		if got := csc.TimesVector(v); !vec.VectorContainsData(got, want) {
+			t.Errorf("Want %v, got %v", want, got)
+		}
+		if got := csc.RowTimesVector(2, v); got != 24 {
+			t.Errorf("Want 24, got %f", got)
+		}
+	})
+
+	t.Run("transpose times vector", func(t *testing.T) {
+		var (
+			v    = vec.MakeWithValues([]float64{1, 2, 3})
+			want = []float64{-2, 9, 1, 15}
+		)
+
+		if got := csc.TransposeTimesVector(v); !vec.VectorContainsData(got, want) {
+			t.Errorf("Want %v, got %v", want, got)
+		}
+	})
+
+	t.Run("times matrix", func(t *testing.T) {
+		var (
+			other = MakeDenseWithData(4, 2, []float64{1, 0, 0, 1, 2, 0, 0, -1})
+			want  = []float64{6, 0, 0, 0, -2, -2}
+		)
+
+		assertMatrixContainsData(t, csc.TimesMatrix(other), want)
+	})
+}
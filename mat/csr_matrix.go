@@ -0,0 +1,148 @@
+package mat
+
+import (
+	"sort"
+
+	"github.com/angelsolaorbaiceta/inkmath/nums"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+/*
+A CSRMat is an immutable sparse matrix stored in Compressed Sparse Row format.
+
+The non-zero values are stored contiguously, row after row, with their column indices sorted
+in ascending order. The values for the row i are stored in the range
+[rowPtr[i], rowPtr[i+1]) of the colIdx and values slices.
+
+CSR matrices are meant to be created once the assembly of a matrix is finished (for example,
+from a SparseMat), and used afterwards in the products with vectors that most solvers require.
+*/
+type CSRMat struct {
+	rows, cols int
+	rowPtr     []int
+	colIdx     []int
+	values     []float64
+}
+
+/*
+MakeCSR creates a new CSR matrix with the non-zero values of the given matrix.
+
+The passed in matrix can be any ReadOnlyMatrix, but it's usually a SparseMat whose assembly
+is finished.
+*/
+func MakeCSR(m ReadOnlyMatrix) *CSRMat {
+	var (
+		rows    = m.Rows()
+		rowPtr  = make([]int, rows+1)
+		colIdx  = make([]int, 0)
+		values  = make([]float64, 0)
+		indices []int
+		val     float64
+	)
+
+	for row := 0; row < rows; row++ {
+		indices = m.NonZeroIndicesAtRow(row)
+		sort.Ints(indices)
+
+		for _, col := range indices {
+			if val = m.Value(row, col); nums.IsCloseToZero(val) {
+				continue
+			}
+
+			colIdx = append(colIdx, col)
+			values = append(values, val)
+		}
+
+		rowPtr[row+1] = len(colIdx)
+	}
+
+	return &CSRMat{rows, m.Cols(), rowPtr, colIdx, values}
+}
+
+// Rows returns the number of rows in the matrix.
+func (m CSRMat) Rows() int { return m.rows }
+
+// Cols returns the number of columns in the matrix.
+func (m CSRMat) Cols() int { return m.cols }
+
+// NonZeroCount returns the number of stored (non-zero) values in the matrix.
+func (m CSRMat) NonZeroCount() int { return len(m.values) }
+
+// Value returns the value at a given row and column.
+func (m CSRMat) Value(row, col int) float64 {
+	var (
+		start = m.rowPtr[row]
+		end   = m.rowPtr[row+1]
+		i     = start + sort.SearchInts(m.colIdx[start:end], col)
+	)
+
+	if i < end && m.colIdx[i] == col {
+		return m.values[i]
+	}
+
+	return 0.0
+}
+
+/*
+NonZeroIndicesAtRow returns a slice with all non-zero elements indices for the given row.
+The indices are sorted in ascending order.
+*/
+func (m CSRMat) NonZeroIndicesAtRow(row int) []int {
+	var (
+		start   = m.rowPtr[row]
+		end     = m.rowPtr[row+1]
+		indices = make([]int, end-start)
+	)
+
+	copy(indices, m.colIdx[start:end])
+
+	return indices
+}
+
+// TimesVector multiplies this matrix and a vector.
+func (m CSRMat) TimesVector(v vec.ReadOnlyVector) vec.ReadOnlyVector {
+	if m.cols != v.Length() {
+		panic("Can't multiply matrix and vector due to size mismatch")
+	}
+
+	result := vec.Make(m.rows)
+
+	for row := 0; row < m.rows; row++ {
+		result.SetValue(row, m.rowTimesVector(row, v))
+	}
+
+	return result
+}
+
+/*
+TimesMatrix multiplies this matrix times other.
+
+The result is a SparseMat whose values are computed accumulating, for each row in this matrix,
+the scaled rows of the other matrix. Only the non-zero values of both matrices are visited.
+*/
+func (m CSRMat) TimesMatrix(other ReadOnlyMatrix) ReadOnlyMatrix {
+	if m.cols != other.Rows() {
+		panic("Can't multiply matrices due to size mismatch")
+	}
+
+	return sparseTimesMatrix(m, other)
+}
+
+// RowTimesVector returns the result of multiplying the row at the given index times the given vector.
+func (m CSRMat) RowTimesVector(row int, v vec.ReadOnlyVector) float64 {
+	if m.cols != v.Length() {
+		panic("Can't multiply matrix row with vector due to size mismatch")
+	}
+
+	return m.rowTimesVector(row, v)
+}
+
+func (m CSRMat) rowTimesVector(row int, v vec.ReadOnlyVector) float64 {
+	result := 0.0
+
+	for i := m.rowPtr[row]; i < m.rowPtr[row+1]; i++ {
+		result += m.values[i] * v.Value(m.colIdx[i])
+	}
+
+	return result
+}
@@ -0,0 +1,56 @@
+package mat
+
+import (
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+func TestCSRMatrix(t *testing.T) {
+	var (
+		data = []float64{
+			4, 0, 1, 0,
+			0, 0, 0, 0,
+			-2, 3, 0, 5,
+		}
+		csr = MakeCSR(MakeSparseWithData(3, 4, data))
+	)
+
+	t.Run("contains the same data as the original matrix", func(t *testing.T) {
+		assertMatrixContainsData(t, csr, data)
+	})
+
+	t.Run("stores only the non-zero values", func(t *testing.T) {
+		if got := csr.NonZeroCount(); got != 5 {
+			t.Errorf("Want 5 non-zero values, got %d", got)
+		}
+	})
+
+	t.Run("non-zero indices at row are sorted", func(t *testing.T) {
+		assertIndices(t, csr.NonZeroIndicesAtRow(2), []int{0, 1, 3})
+		assertIndices(t, csr.NonZeroIndicesAtRow(1), []int{})
+	})
+
+	t.Run("times vector", func(t *testing.T) {
+		var (
+			v    = vec.MakeWithValues([]float64{1, 2, 3, 4})
+			want = []float64{7, 0, 24}
+		)
+
+		if got := csr.TimesVector(v); !vec.VectorContainsData(got, want) {
+			t.Errorf("Want %v, got %v", want, got)
+		}
+		if got := csr.RowTimesVector(2, v); got != 24 {
+			t.Errorf("Want 24, got %f", got)
+		}
+	})
+
+	t.Run("times matrix", func(t *testing.T) {
+		var (
+			other = MakeDenseWithData(4, 2, []float64{1, 0, 0, 1, 2, 0, 0, -1})
+			want  = []float64{6, 0, 0, 0, -2, -2}
+		)
+
+		assertMatrixContainsData(t, csr.TimesMatrix(other), want)
+	})
+}
@@ -0,0 +1,17 @@
+package mat
+
+import "fmt"
+
+/*
+A ZeroPivotError is returned by the matrix factorizations when a zero pivot is found, which
+means the factorized matrix is singular (or not positive definite, for those factorizations
+requiring it).
+*/
+type ZeroPivotError struct {
+	// Index is the row (and column) of the main diagonal where the zero pivot was found.
+	Index int
+}
+
+func (err ZeroPivotError) Error() string {
+	return fmt.Sprintf("zero pivot found at index %d: the matrix is singular", err.Index)
+}
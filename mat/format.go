@@ -0,0 +1,176 @@
+package mat
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// formatMaxSize is the largest number of rows or columns formatted without eliding the middle.
+	formatMaxSize = 10
+	// formatEdgeSize is the number of rows or columns formatted at each side of the elided middle.
+	formatEdgeSize = 4
+)
+
+/*
+Format implements the fmt.Formatter interface, so the matrix can be printed using the %v, %f, %F,
+%e, %E, %g and %G verbs, which are applied to each value. The width and precision are honored, as
+well as the '+', ' ' and '-' flags, and the columns are aligned:
+
+	⎡1.000  2.000⎤
+	⎣3.000  4.000⎦
+
+Matrices with more than ten rows or columns have their middle rows and columns elided, unless the
+'#' flag is used.
+*/
+func (m DenseMat) Format(f fmt.State, verb rune) {
+	formatMatrix(f, verb, m)
+}
+
+// Format implements the fmt.Formatter interface. See DenseMat.Format for the details.
+func (m SparseMat) Format(f fmt.State, verb rune) {
+	formatMatrix(f, verb, m)
+}
+
+func formatMatrix(f fmt.State, verb rune, m ReadOnlyMatrix) {
+	valueFormat, ok := formatVerb(f, verb)
+	if !ok {
+		fmt.Fprintf(f, "%%!%c(%T=%dx%d)", verb, m, m.Rows(), m.Cols())
+		return
+	}
+
+	if m.Rows() == 0 || m.Cols() == 0 {
+		fmt.Fprint(f, "[]")
+		return
+	}
+
+	var (
+		elide = !f.Flag('#')
+		rows  = formatIndices(m.Rows(), elide)
+		cols  = formatIndices(m.Cols(), elide)
+		cells = make([][]string, len(rows))
+	)
+
+	// Format the values and compute the width of each column
+	width, _ := f.Width()
+	colWidths := make([]int, len(cols))
+	for i := range colWidths {
+		colWidths[i] = width
+	}
+
+	for i, row := range rows {
+		cells[i] = make([]string, len(cols))
+
+		for j, col := range cols {
+			switch {
+			case row < 0:
+				cells[i][j] = "⋮"
+			case col < 0:
+				cells[i][j] = "…"
+			default:
+				cells[i][j] = fmt.Sprintf(valueFormat, m.Value(row, col))
+			}
+
+			if cellWidth := utf8.RuneCountInString(cells[i][j]); cellWidth > colWidths[j] {
+				colWidths[j] = cellWidth
+			}
+		}
+	}
+
+	var (
+		leftAlign = f.Flag('-')
+		builder   strings.Builder
+	)
+
+	for i := range rows {
+		left, right := matrixBrackets(i, len(rows))
+		builder.WriteString(left)
+
+		for j := range cols {
+			if j > 0 {
+				builder.WriteString("  ")
+			}
+
+			padding := strings.Repeat(" ", colWidths[j]-utf8.RuneCountInString(cells[i][j]))
+			if leftAlign {
+				builder.WriteString(cells[i][j] + padding)
+			} else {
+				builder.WriteString(padding + cells[i][j])
+			}
+		}
+
+		builder.WriteString(right)
+		if i < len(rows)-1 {
+			builder.WriteByte('\n')
+		}
+	}
+
+	fmt.Fprint(f, builder.String())
+}
+
+/*
+formatVerb returns the format used to print each of the values of a matrix given the formatting
+state and verb, or false if the verb isn't supported.
+*/
+func formatVerb(f fmt.State, verb rune) (string, bool) {
+	switch verb {
+	case 'v':
+		verb = 'g'
+	case 'f', 'F', 'e', 'E', 'g', 'G':
+	default:
+		return "", false
+	}
+
+	format := "%"
+	if f.Flag('+') {
+		format += "+"
+	} else if f.Flag(' ') {
+		format += " "
+	}
+	if precision, ok := f.Precision(); ok {
+		format += fmt.Sprintf(".%d", precision)
+	}
+
+	return format + string(verb), true
+}
+
+/*
+formatIndices returns the indices of the rows or columns to format. If elide is true and there are
+too many of them, only the first and last ones are included, with a -1 in the middle.
+*/
+func formatIndices(size int, elide bool) []int {
+	indices := make([]int, 0, size)
+
+	if !elide || size <= formatMaxSize {
+		for i := 0; i < size; i++ {
+			indices = append(indices, i)
+		}
+
+		return indices
+	}
+
+	for i := 0; i < formatEdgeSize; i++ {
+		indices = append(indices, i)
+	}
+	indices = append(indices, -1)
+	for i := size - formatEdgeSize; i < size; i++ {
+		indices = append(indices, i)
+	}
+
+	return indices
+}
+
+// matrixBrackets returns the left and right bracket pieces for the given row.
+func matrixBrackets(row, rows int) (string, string) {
+	switch {
+	case rows == 1:
+		return "[", "]"
+	case row == 0:
+		return "⎡", "⎤"
+	case row == rows-1:
+		return "⎣", "⎦"
+	default:
+		return "⎢", "⎥"
+	}
+}
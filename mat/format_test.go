@@ -0,0 +1,104 @@
+package mat
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestFormatMatrix(t *testing.T) {
+	m := MakeDenseWithData(2, 2, []float64{1, -2.5, 30, 4})
+
+	t.Run("aligns the columns", func(t *testing.T) {
+		want := "⎡ 1  -2.5⎤\n⎣30     4⎦"
+		if got := fmt.Sprintf("%v", m); got != want {
+			t.Errorf("Want\n%s\ngot\n%s", want, got)
+		}
+	})
+
+	t.Run("honors width and precision", func(t *testing.T) {
+		want := "⎡   1.00    -2.50⎤\n⎣  30.00     4.00⎦"
+		if got := fmt.Sprintf("%7.2f", m); got != want {
+			t.Errorf("Want\n%s\ngot\n%s", want, got)
+		}
+	})
+
+	t.Run("honors flags", func(t *testing.T) {
+		want := "[1      -2.5 ]"
+		if got := fmt.Sprintf("%-5v", MakeDenseWithData(1, 2, []float64{1, -2.5})); got != want {
+			t.Errorf("Want %s, got %s", want, got)
+		}
+
+		want = "[+1.0  +0.0]"
+		if got := fmt.Sprintf("%+.1f", MakeDenseWithData(1, 2, []float64{1, 0})); got != want {
+			t.Errorf("Want %s, got %s", want, got)
+		}
+	})
+
+	t.Run("sparse matrix", func(t *testing.T) {
+		want := "⎡1  0⎤\n⎣0  1⎦"
+		if got := fmt.Sprintf("%v", MakeIdentity(2)); got != want {
+			t.Errorf("Want\n%s\ngot\n%s", want, got)
+		}
+	})
+
+	t.Run("elides the middle of large matrices", func(t *testing.T) {
+		var (
+			got   = fmt.Sprintf("%v", MakeIdentity(20))
+			lines = strings.Split(got, "\n")
+		)
+
+		if len(lines) != 9 {
+			t.Fatalf("Want 9 lines, got %d:\n%s", len(lines), got)
+		}
+		if want := "⎡1  0  0  0  …  0  0  0  0⎤"; lines[0] != want {
+			t.Errorf("Want %s, got %s", want, lines[0])
+		}
+		if want := "⎢⋮  ⋮  ⋮  ⋮  ⋮  ⋮  ⋮  ⋮  ⋮⎥"; lines[4] != want {
+			t.Errorf("Want %s, got %s", want, lines[4])
+		}
+		if want := "⎣0  0  0  0  …  0  0  0  1⎦"; lines[8] != want {
+			t.Errorf("Want %s, got %s", want, lines[8])
+		}
+
+		if lines := strings.Split(fmt.Sprintf("%#v", MakeIdentity(20)), "\n"); len(lines) != 20 {
+			t.Errorf("Want 20 lines without elision, got %d", len(lines))
+		}
+	})
+
+	t.Run("unsupported verb", func(t *testing.T) {
+		want := "%!d(mat.DenseMat=2x2)"
+		if got := fmt.Sprintf("%d", *m); got != want {
+			t.Errorf("Want %s, got %s", want, got)
+		}
+	})
+}
+
+func TestBrailleSpy(t *testing.T) {
+	t.Run("one dot per entry", func(t *testing.T) {
+		want := "⣇⠀\n⠀⠑\n"
+		m := MakeSparse(6, 4)
+		for i := 0; i < 4; i++ {
+			m.SetValue(i, 0, 1.0)
+		}
+		m.SetValue(3, 1, 1.0)
+		m.SetValue(4, 2, 1.0)
+		m.SetValue(5, 3, 1.0)
+
+		if got := BrailleSpy(m, 10); got != want {
+			t.Errorf("Want\n%s\ngot\n%s", want, got)
+		}
+	})
+
+	t.Run("downsamples wide matrices", func(t *testing.T) {
+		var (
+			got   = BrailleSpy(MakeIdentity(16), 2)
+			want  = "⠑⢄\n"
+			lines = strings.Split(got, "\n")
+		)
+
+		if len(lines) != 2 || got != want {
+			t.Errorf("Want\n%s\ngot\n%s", want, got)
+		}
+	})
+}
@@ -0,0 +1,388 @@
+package mat
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+/*
+A HarwellBoeingError is returned when a Harwell-Boeing file can't be read because it's malformed
+or uses a feature which isn't supported.
+*/
+type HarwellBoeingError struct {
+	// Line is the number of the line, starting at one, where the error was found.
+	Line int
+	Msg  string
+}
+
+func (err HarwellBoeingError) Error() string {
+	return fmt.Sprintf("harwell-boeing: line %d: %s", err.Line, err.Msg)
+}
+
+// A HarwellBoeingMatrix is the content read from a Harwell-Boeing (or Rutherford-Boeing) file.
+type HarwellBoeingMatrix struct {
+	Title string
+	Key   string
+	// Type is the three letter matrix type code, for example "RSA" for real symmetric assembled.
+	Type string
+	// Matrix has all the values of the matrix, including both triangles for symmetric matrices.
+	Matrix *CSCMat
+	// RightHandSides has the right-hand side vectors included in the file, if any.
+	RightHandSides []vec.ReadOnlyVector
+}
+
+/*
+ReadHarwellBoeing reads a matrix in Harwell-Boeing format, as well as the Rutherford-Boeing
+variant without right-hand sides.
+
+Assembled real, integer or pattern matrices are supported (pattern values are read as ones), with
+unsymmetric, symmetric or skew-symmetric storage. For the symmetric ones, only the lower triangle
+is stored in the file, so it's expanded to obtain the full matrix.
+
+Full (not sparse) right-hand sides are read if present. The starting guesses and exact solutions
+that may follow them are ignored.
+
+Any problem reading the file is returned as a HarwellBoeingError with the offending line number.
+*/
+func ReadHarwellBoeing(r io.Reader) (*HarwellBoeingMatrix, error) {
+	var (
+		reader = &cardReader{scanner: bufio.NewScanner(r)}
+		result = &HarwellBoeingMatrix{}
+		err    error
+	)
+
+	fail := func(format string, args ...interface{}) (*HarwellBoeingMatrix, error) {
+		return nil, HarwellBoeingError{reader.line, fmt.Sprintf(format, args...)}
+	}
+
+	// Line 1: title and key
+	card, ok := reader.next()
+	if !ok {
+		return fail("missing title line")
+	}
+	result.Title = strings.TrimSpace(fixedField(card, 0, 72))
+	result.Key = strings.TrimSpace(fixedField(card, 72, 80))
+
+	// Line 2: number of lines for each section
+	if card, ok = reader.next(); !ok {
+		return fail("missing line counts")
+	}
+	counts, err := fixedInts(card, 5)
+	if err != nil {
+		return fail("%v", err)
+	}
+	var (
+		valueLines = counts[3]
+		rhsLines   = counts[4]
+	)
+
+	// Line 3: matrix type and sizes
+	if card, ok = reader.next(); !ok {
+		return fail("missing matrix type and sizes")
+	}
+	result.Type = strings.ToUpper(strings.TrimSpace(fixedField(card, 0, 3)))
+	if len(result.Type) != 3 {
+		return fail("invalid matrix type %q", result.Type)
+	}
+	sizes, err := fixedInts(fixedField(card, 14, len(card)), 3)
+	if err != nil {
+		return fail("%v", err)
+	}
+	var (
+		rows, cols, nnz = sizes[0], sizes[1], sizes[2]
+		valueType       = result.Type[0]
+		structure       = result.Type[1]
+	)
+
+	if rows < 0 || cols < 0 || nnz < 0 {
+		return fail("invalid matrix sizes %d, %d, %d", rows, cols, nnz)
+	}
+	if productOverflows(rows, cols) || nnz > rows*cols {
+		return fail("%d entries don't fit in a %dx%d matrix", nnz, rows, cols)
+	}
+	if valueType != 'R' && valueType != 'P' && valueType != 'I' {
+		return fail("unsupported value type %q", valueType)
+	}
+	if structure != 'U' && structure != 'S' && structure != 'Z' && structure != 'R' {
+		return fail("unsupported matrix structure %q", structure)
+	}
+	if result.Type[2] != 'A' {
+		return fail("only assembled matrices are supported, got %q", result.Type[2])
+	}
+
+	// Line 4: formats
+	if card, ok = reader.next(); !ok {
+		return fail("missing formats")
+	}
+	ptrFormat, err := parseFortranFormat(fixedField(card, 0, 16))
+	if err != nil {
+		return fail("%v", err)
+	}
+	indFormat, err := parseFortranFormat(fixedField(card, 16, 32))
+	if err != nil {
+		return fail("%v", err)
+	}
+	var valFormat, rhsFormat fortranFormat
+	if valueType != 'P' && valueLines > 0 {
+		if valFormat, err = parseFortranFormat(fixedField(card, 32, 52)); err != nil {
+			return fail("%v", err)
+		}
+	}
+
+	// Line 5: right-hand sides information
+	var rhsType string
+	var rhsCount int
+	if rhsLines > 0 {
+		if rhsFormat, err = parseFortranFormat(fixedField(card, 52, 72)); err != nil {
+			return fail("%v", err)
+		}
+		if card, ok = reader.next(); !ok {
+			return fail("missing right-hand sides information")
+		}
+
+		rhsType = strings.ToUpper(fixedField(card, 0, 3))
+		rhsSizes, err := fixedInts(fixedField(card, 14, len(card)), 1)
+		if err != nil {
+			return fail("%v", err)
+		}
+		rhsCount = rhsSizes[0]
+
+		if rhsCount < 0 || productOverflows(rhsCount, rows) {
+			return fail("invalid number of right-hand sides %d", rhsCount)
+		}
+
+		if !strings.HasPrefix(rhsType, "F") {
+			return fail("only full right-hand sides are supported, got %q", rhsType)
+		}
+	}
+
+	// Column pointers, row indices and values
+	pointers, err := reader.readInts(ptrFormat, cols+1)
+	if err != nil {
+		return nil, err
+	}
+	indices, err := reader.readInts(indFormat, nnz)
+	if err != nil {
+		return nil, err
+	}
+	var values []float64
+	if valueType == 'P' || valueLines == 0 {
+		values = make([]float64, nnz)
+		for i := range values {
+			values[i] = 1.0
+		}
+	} else if values, err = reader.readFloats(valFormat, nnz); err != nil {
+		return nil, err
+	}
+
+	builder := MakeTripletBuilder(rows, cols, 2*nnz)
+	for col := 0; col < cols; col++ {
+		if pointers[col] < 1 || pointers[col+1] < pointers[col] || pointers[col+1] > nnz+1 {
+			return fail("invalid column pointers for column %d", col+1)
+		}
+
+		for i := pointers[col] - 1; i < pointers[col+1]-1; i++ {
+			row := indices[i] - 1
+			if row < 0 || row >= rows {
+				return fail("row index %d out of the matrix bounds", indices[i])
+			}
+
+			builder.Add(row, col, values[i])
+
+			if row != col {
+				switch structure {
+				case 'S':
+					builder.Add(col, row, values[i])
+				case 'Z':
+					builder.Add(col, row, -values[i])
+				}
+			}
+		}
+	}
+	result.Matrix = MakeCSC(builder.ToCSR())
+
+	// Right-hand sides
+	if rhsCount > 0 {
+		rhsValues, err := reader.readFloats(rhsFormat, rhsCount*rows)
+		if err != nil {
+			return nil, err
+		}
+
+		result.RightHandSides = make([]vec.ReadOnlyVector, rhsCount)
+		for i := 0; i < rhsCount; i++ {
+			result.RightHandSides[i] = vec.MakeWithValues(rhsValues[i*rows : (i+1)*rows])
+		}
+	}
+
+	return result, nil
+}
+
+// A cardReader reads the lines (or cards) of a file keeping track of the line number.
+type cardReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func (r *cardReader) next() (string, bool) {
+	if !r.scanner.Scan() {
+		return "", false
+	}
+
+	r.line++
+	return strings.TrimRight(r.scanner.Text(), "\r"), true
+}
+
+/*
+readFields reads count fixed-width fields, starting at a new line, and calls the parse function
+with each of them.
+*/
+func (r *cardReader) readFields(
+	format fortranFormat,
+	count int,
+	parse func(i int, field string) error,
+) error {
+	for read := 0; read < count; {
+		card, ok := r.next()
+		if !ok {
+			return HarwellBoeingError{
+				r.line,
+				fmt.Sprintf("unexpected end of file, want %d values, got %d", count, read),
+			}
+		}
+
+		for i := 0; i < format.perLine && read < count; i++ {
+			field := strings.TrimSpace(fixedField(card, i*format.width, (i+1)*format.width))
+			if err := parse(read, field); err != nil {
+				return HarwellBoeingError{r.line, err.Error()}
+			}
+
+			read++
+		}
+	}
+
+	return nil
+}
+
+/*
+readInts reads count integers. The slice grows as they're read, instead of being allocated in
+advance, so that a wrong count in the header doesn't allocate more memory than the file needs.
+*/
+func (r *cardReader) readInts(format fortranFormat, count int) ([]int, error) {
+	ints := make([]int, 0)
+
+	err := r.readFields(format, count, func(_ int, field string) error {
+		value, err := strconv.Atoi(field)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", field)
+		}
+
+		ints = append(ints, value)
+		return nil
+	})
+
+	return ints, err
+}
+
+// readFloats reads count numbers, growing the slice as they're read like readInts.
+func (r *cardReader) readFloats(format fortranFormat, count int) ([]float64, error) {
+	floats := make([]float64, 0)
+
+	err := r.readFields(format, count, func(_ int, field string) error {
+		value, err := parseFortranFloat(field)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", field)
+		}
+
+		floats = append(floats, value)
+		return nil
+	})
+
+	return floats, err
+}
+
+/*
+A fortranFormat is a Fortran edit descriptor, like (10I8) or (1P,4D20.12), describing how many
+fixed-width fields are there in each line and their width.
+*/
+type fortranFormat struct {
+	perLine, width int
+}
+
+var fortranFormatRegexp = regexp.MustCompile(
+	`^\(\s*(?:[+-]?\d+P\s*,?\s*)?(\d*)\s*([IEDFG])\s*(\d+)(?:\.\d+)?(?:E\d+)?\s*\)$`,
+)
+
+func parseFortranFormat(text string) (fortranFormat, error) {
+	match := fortranFormatRegexp.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(text)))
+	if match == nil {
+		return fortranFormat{}, fmt.Errorf("unsupported Fortran format %q", strings.TrimSpace(text))
+	}
+
+	perLine := 1
+	if match[1] != "" {
+		perLine, _ = strconv.Atoi(match[1])
+	}
+	width, _ := strconv.Atoi(match[3])
+
+	if perLine < 1 || width < 1 {
+		return fortranFormat{}, fmt.Errorf("invalid Fortran format %q", strings.TrimSpace(text))
+	}
+
+	return fortranFormat{perLine, width}, nil
+}
+
+/*
+parseFortranFloat parses a number written by Fortran, where the exponent can be marked with a D
+instead of an E, or even omitted, as in 1.5-03.
+*/
+func parseFortranFloat(text string) (float64, error) {
+	text = strings.NewReplacer("D", "E", "d", "E", "e", "E").Replace(text)
+
+	if !strings.Contains(text, "E") {
+		if i := strings.LastIndexAny(text, "+-"); i > 0 {
+			text = text[:i] + "E" + text[i:]
+		}
+	}
+
+	return strconv.ParseFloat(text, 64)
+}
+
+// fixedField returns the text between the given columns of a line, or an empty string if the
+// line is shorter.
+func fixedField(card string, start, end int) string {
+	if start >= len(card) {
+		return ""
+	}
+	if end > len(card) {
+		end = len(card)
+	}
+
+	return card[start:end]
+}
+
+// fixedInts parses count integers stored in fields of 14 columns. Empty fields are read as zeroes.
+func fixedInts(card string, count int) ([]int, error) {
+	var (
+		ints = make([]int, count)
+		err  error
+	)
+
+	for i := 0; i < count; i++ {
+		field := strings.TrimSpace(fixedField(card, i*14, (i+1)*14))
+		if field == "" {
+			continue
+		}
+
+		if ints[i], err = strconv.Atoi(field); err != nil {
+			return nil, fmt.Errorf("invalid integer %q", field)
+		}
+	}
+
+	return ints, nil
+}
@@ -0,0 +1,139 @@
+package mat
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkmath/nums"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+func makeHarwellBoeingFile(indices string) string {
+	lines := []string{
+		fmt.Sprintf("%-72s%-8s", "Symmetric test matrix", "TEST"),
+		fmt.Sprintf("%14d%14d%14d%14d%14d", 6, 1, 1, 2, 1),
+		fmt.Sprintf("%-14s%14d%14d%14d%14d", "RSA", 3, 3, 5, 0),
+		fmt.Sprintf("%-16s%-16s%-20s%-20s", "(10I4)", "(10I4)", "(3D16.8)", "(1P,3E16.8)"),
+		fmt.Sprintf("%-14s%14d%14d", "F", 1, 0),
+		fmt.Sprintf("%4d%4d%4d%4d", 1, 3, 5, 6),
+		indices,
+		fmt.Sprintf("%16s%16s%16s", "0.40000000D+01", "0.10000000D+01", "0.30000000D+01"),
+		fmt.Sprintf("%16s%16s", "0.20000000D+01", "5.0"),
+		fmt.Sprintf("%16s%16s%16s", "5.0E+00", "6.0E+00", "7.0E+00"),
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func TestReadHarwellBoeing(t *testing.T) {
+	t.Run("reads a symmetric matrix and its right-hand side", func(t *testing.T) {
+		file := makeHarwellBoeingFile(fmt.Sprintf("%4d%4d%4d%4d%4d", 1, 2, 2, 3, 3))
+
+		hb, err := ReadHarwellBoeing(strings.NewReader(file))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if hb.Title != "Symmetric test matrix" || hb.Key != "TEST" || hb.Type != "RSA" {
+			t.Errorf("Wrong header: %q, %q, %q", hb.Title, hb.Key, hb.Type)
+		}
+
+		assertMatrixContainsData(t, hb.Matrix, []float64{
+			4, 1, 0,
+			1, 3, 2,
+			0, 2, 5,
+		})
+
+		if len(hb.RightHandSides) != 1 {
+			t.Fatalf("Want 1 right-hand side, got %d", len(hb.RightHandSides))
+		}
+		if want := []float64{5, 6, 7}; !vec.VectorContainsData(hb.RightHandSides[0], want) {
+			t.Errorf("Want right-hand side %v, got %v", want, hb.RightHandSides[0])
+		}
+	})
+
+	t.Run("errors report the line number", func(t *testing.T) {
+		file := makeHarwellBoeingFile(fmt.Sprintf("%4d%4d%4s%4d%4d", 1, 2, "x", 3, 3))
+
+		_, err := ReadHarwellBoeing(strings.NewReader(file))
+
+		var hbErr HarwellBoeingError
+		if !errors.As(err, &hbErr) {
+			t.Fatalf("Want a HarwellBoeingError, got %v", err)
+		}
+		if hbErr.Line != 7 {
+			t.Errorf("Want error at line 7, got %d", hbErr.Line)
+		}
+	})
+
+	t.Run("rejects invalid sizes in the header", func(t *testing.T) {
+		var (
+			file      = makeHarwellBoeingFile(fmt.Sprintf("%4d%4d%4d%4d%4d", 1, 2, 3, 2, 3))
+			sizesLine = fmt.Sprintf("%-14s%14d%14d%14d%14d", "RSA", 3, 3, 5, 0)
+			rhsLine   = fmt.Sprintf("%-14s%14d%14d", "F", 1, 0)
+			tests     = []struct {
+				old, new string
+				line     int
+			}{
+				{sizesLine, fmt.Sprintf("%-14s%14d%14d%14d%14d", "RSA", 3, -5, 5, 0), 3},
+				{sizesLine, fmt.Sprintf("%-14s%14d%14d%14d%14d", "RSA", -3, 3, 5, 0), 3},
+				{sizesLine, fmt.Sprintf("%-14s%14d%14d%14d%14d", "RSA", 3, 3, 1000000, 0), 3},
+				{rhsLine, fmt.Sprintf("%-14s%14d%14d", "F", -1, 0), 5},
+			}
+		)
+
+		for _, test := range tests {
+			_, err := ReadHarwellBoeing(strings.NewReader(strings.Replace(file, test.old, test.new, 1)))
+
+			var hbErr HarwellBoeingError
+			if !errors.As(err, &hbErr) {
+				t.Fatalf("Want a HarwellBoeingError, got %v", err)
+			}
+			if hbErr.Line != test.line {
+				t.Errorf("Want error at line %d, got %d", test.line, hbErr.Line)
+			}
+		}
+	})
+}
+
+func TestParseFortranFormat(t *testing.T) {
+	cases := []struct {
+		format         string
+		perLine, width int
+	}{
+		{"(10I8)", 10, 8},
+		{"(5E16.8)", 5, 16},
+		{"(1P,4D20.12)", 4, 20},
+		{"(1P5E15.7)", 5, 15},
+		{"(I10)", 1, 10},
+	}
+
+	for _, testCase := range cases {
+		got, err := parseFortranFormat(testCase.format)
+		if err != nil {
+			t.Errorf("Unexpected error parsing %s: %v", testCase.format, err)
+		}
+		if got.perLine != testCase.perLine || got.width != testCase.width {
+			t.Errorf("Want %d fields of %d for %s, got %v", testCase.perLine, testCase.width, testCase.format, got)
+		}
+	}
+}
+
+func TestParseFortranFloat(t *testing.T) {
+	cases := map[string]float64{
+		"1.5":      1.5,
+		"-2.0D+01": -20,
+		"0.25d-1":  0.025,
+		"1.5-03":   0.0015,
+		"-3.0+2":   -300,
+		"4.0E00":   4,
+	}
+
+	for text, want := range cases {
+		if got, err := parseFortranFloat(text); err != nil || !nums.FloatsEqual(got, want) {
+			t.Errorf("Want %f for %q, got %f (%v)", want, text, got, err)
+		}
+	}
+}
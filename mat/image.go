@@ -1,51 +1,363 @@
 package mat
 
 import (
+	"errors"
 	"image"
 	"image/color"
 	"image/png"
+	"io"
+	"math"
 	"os"
-
-	"github.com/angelsolaorbaiceta/inkmath/nums"
 )
 
 var (
-	gray = color.RGBA{230, 230, 230, 255}
-	red  = color.RGBA{255, 0, 0, 255}
-	blue = color.RGBA{0, 0, 255, 255}
+	gray  = color.RGBA{230, 230, 230, 255}
+	red   = color.RGBA{255, 0, 0, 255}
+	blue  = color.RGBA{0, 0, 255, 255}
+	white = color.RGBA{255, 255, 255, 255}
+	black = color.RGBA{0, 0, 0, 255}
+)
+
+// ErrEmptyImage is returned when rendering a matrix without rows or columns.
+var ErrEmptyImage = errors.New("mat: can't render a matrix without rows or columns")
+
+// An ImageAggregation decides the value of a block of matrix entries rendered as a single pixel.
+type ImageAggregation int
+
+const (
+	// DensityAggregation uses the fraction of non-zero entries in the block.
+	DensityAggregation ImageAggregation = iota
+	// MaxMagnitudeAggregation uses the largest absolute value in the block.
+	MaxMagnitudeAggregation
+)
+
+// An ImageColorScale decides how the aggregated block values are turned into colors.
+type ImageColorScale int
+
+const (
+	/*
+		SignColorScale paints the blocks red if their largest magnitude value is positive and blue if
+		it's negative. Using the density aggregation, sparser blocks get lighter colors.
+	*/
+	SignColorScale ImageColorScale = iota
+	// HeatmapColorScale paints the blocks using a color gradient between the min and max values.
+	HeatmapColorScale
 )
 
+// ImageOptions configures how a matrix is rendered into an image.
+type ImageOptions struct {
+	/*
+		Size is the number of pixels of the largest side of the matrix in the image. Matrices with
+		more rows or columns are downsampled, rendering square blocks of entries as a single pixel,
+		and smaller matrices are upsampled, rendering each entry as a square of pixels.
+	*/
+	Size int
+	// ZeroTolerance is the absolute value below or equal to which entries are considered zero.
+	ZeroTolerance float64
+	Aggregation   ImageAggregation
+	ColorScale    ImageColorScale
+	// LogScale maps the heatmap colors using the logarithm of the aggregated values.
+	LogScale bool
+	// Legend adds a color bar with the min and max values next to heatmap images.
+	Legend bool
+}
+
+// DefaultImageOptions returns the options used by ToImage.
+func DefaultImageOptions() ImageOptions {
+	return ImageOptions{
+		Size:          1000,
+		ZeroTolerance: 1e-10,
+		Aggregation:   DensityAggregation,
+		ColorScale:    SignColorScale,
+	}
+}
+
 /*
-ToImage creates an image with as many width pixels as columns has the matrix and as many
-height pixels as rows.
+ToImage renders the sparsity pattern of the matrix into a PNG image using the default options,
+and writes it to the given file path. The file is created, or truncated if it already exists.
 
 Each pixel will be colored:
-	- Gray if the value is zero
-	- Red if the value is positive
-	- Blue if the value is negative
+	- Gray if the values are zero
+	- Red if the largest magnitude value is positive
+	- Blue if the largest magnitude value is negative
+*/
+func ToImage(m ReadOnlyMatrix, filePath string) (err error) {
+	f, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	return RenderPNG(f, m, DefaultImageOptions())
+}
+
+/*
+RenderPNG renders the matrix into a PNG image which is written to w.
+
+The rows of the matrix are rendered top to bottom and the columns left to right. Large matrices
+are downsampled so that their largest side fits in options.Size pixels: each pixel represents a
+square block of entries, aggregated using options.Aggregation. Blocks without non-zero values are
+rendered in gray.
 */
-func ToImage(m ReadOnlyMatrix, filePath string) {
+func RenderPNG(w io.Writer, m ReadOnlyMatrix, options ImageOptions) error {
+	img, err := Render(m, options)
+	if err != nil {
+		return err
+	}
+
+	return png.Encode(w, img)
+}
+
+// Render renders the matrix into an image. See RenderPNG for the details.
+func Render(m ReadOnlyMatrix, options ImageOptions) (*image.RGBA, error) {
+	if m.Rows() == 0 || m.Cols() == 0 {
+		return nil, ErrEmptyImage
+	}
+	if options.Size <= 0 {
+		return nil, errors.New("mat: the image size must be positive")
+	}
+
 	var (
-		width  = m.Cols()
-		height = m.Rows()
-		img    = image.NewRGBA(image.Rectangle{image.Point{0, 0}, image.Point{width, height}})
-		val    float64
+		blocks         = aggregateBlocks(m, options)
+		minVal, maxVal = blocks.valueRange(options.Aggregation)
+		hasLegend      = options.Legend && options.ColorScale == HeatmapColorScale
+		width          = blocks.cols * blocks.pixelSize
+		height         = blocks.rows * blocks.pixelSize
+		imgWidth       = width
+		imgHeight      = height
 	)
 
-	for row := 0; row < height; row++ {
-		for col := 0; col < width; col++ {
-			val = m.Value(row, col)
-			if nums.IsCloseToZero(val) {
-				img.Set(row, col, gray)
-			} else if val > 0.0 {
-				img.Set(row, col, red)
-			} else {
-				img.Set(row, col, blue)
+	if options.LogScale {
+		minVal, maxVal = math.Log10(minVal), math.Log10(maxVal)
+	}
+
+	if hasLegend {
+		imgWidth += legendWidth(minVal, maxVal, options.LogScale)
+		if imgHeight < legendMinHeight {
+			imgHeight = legendMinHeight
+		}
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, imgWidth, imgHeight))
+	fillRect(img, img.Bounds(), white)
+
+	for blockRow := 0; blockRow < blocks.rows; blockRow++ {
+		for blockCol := 0; blockCol < blocks.cols; blockCol++ {
+			var (
+				i = blockRow*blocks.cols + blockCol
+				c = gray
+			)
+
+			if blocks.count[i] > 0 {
+				value := blocks.value(i, options.Aggregation)
+
+				if options.ColorScale == HeatmapColorScale {
+					if options.LogScale {
+						value = math.Log10(value)
+					}
+					c = heatmapColor(normalize(value, minVal, maxVal))
+				} else {
+					c = signColor(blocks.signed[i], value, options.Aggregation)
+				}
 			}
+
+			fillRect(img, image.Rect(
+				blockCol*blocks.pixelSize,
+				blockRow*blocks.pixelSize,
+				(blockCol+1)*blocks.pixelSize,
+				(blockRow+1)*blocks.pixelSize,
+			), c)
 		}
 	}
 
-	f, _ := os.OpenFile(filePath+"_sysmat.png", os.O_WRONLY|os.O_CREATE, 0600)
-	defer f.Close()
-	png.Encode(f, img)
+	if hasLegend {
+		drawLegend(img, width, minVal, maxVal, options.LogScale)
+	}
+
+	return img, nil
+}
+
+// imageBlocks has the aggregated values of the square blocks of entries rendered as pixels.
+type imageBlocks struct {
+	rows, cols int
+	// blockSize is the number of matrix rows and columns in each block.
+	blockSize int
+	// pixelSize is the number of image pixels rendering each side of a block.
+	pixelSize int
+	// entries is the number of matrix entries in each block, which is smaller for the last ones.
+	entries []int
+	count   []int
+	maxAbs  []float64
+	// signed is the value with the largest magnitude in each block.
+	signed []float64
+}
+
+func aggregateBlocks(m ReadOnlyMatrix, options ImageOptions) *imageBlocks {
+	var (
+		rows, cols = m.Rows(), m.Cols()
+		largest    = rows
+		blockSize  = 1
+		pixelSize  = 1
+	)
+
+	if cols > largest {
+		largest = cols
+	}
+
+	if largest > options.Size {
+		blockSize = (largest + options.Size - 1) / options.Size
+	} else {
+		pixelSize = options.Size / largest
+	}
+
+	var (
+		blockRows = (rows + blockSize - 1) / blockSize
+		blockCols = (cols + blockSize - 1) / blockSize
+		size      = blockRows * blockCols
+		blocks    = &imageBlocks{
+			rows:      blockRows,
+			cols:      blockCols,
+			blockSize: blockSize,
+			pixelSize: pixelSize,
+			entries:   make([]int, size),
+			count:     make([]int, size),
+			maxAbs:    make([]float64, size),
+			signed:    make([]float64, size),
+		}
+	)
+
+	for blockRow := 0; blockRow < blockRows; blockRow++ {
+		height := blockSizeAt(blockRow, blockSize, rows)
+		for blockCol := 0; blockCol < blockCols; blockCol++ {
+			blocks.entries[blockRow*blockCols+blockCol] = height * blockSizeAt(blockCol, blockSize, cols)
+		}
+	}
+
+	for row := 0; row < rows; row++ {
+		// Every stored value is visited, so only the caller's tolerance decides what's a zero
+		ForEachNonZeroInRow(m, row, func(col int, value float64) {
+			if math.Abs(value) <= options.ZeroTolerance {
+				return
+			}
+
+			i := (row/blockSize)*blockCols + col/blockSize
+			blocks.count[i]++
+			if math.Abs(value) > blocks.maxAbs[i] {
+				blocks.maxAbs[i] = math.Abs(value)
+				blocks.signed[i] = value
+			}
+		})
+	}
+
+	return blocks
+}
+
+func blockSizeAt(block, blockSize, total int) int {
+	if end := (block + 1) * blockSize; end > total {
+		return total - block*blockSize
+	}
+
+	return blockSize
+}
+
+func (b *imageBlocks) value(i int, aggregation ImageAggregation) float64 {
+	if aggregation == MaxMagnitudeAggregation {
+		return b.maxAbs[i]
+	}
+
+	return float64(b.count[i]) / float64(b.entries[i])
+}
+
+// valueRange returns the min and max aggregated values of the blocks with non-zero entries,
+// or one for both if all the blocks are zero.
+func (b *imageBlocks) valueRange(aggregation ImageAggregation) (float64, float64) {
+	var (
+		minVal, maxVal = math.Inf(1), math.Inf(-1)
+		found          = false
+	)
+
+	for i, count := range b.count {
+		if count == 0 {
+			continue
+		}
+
+		value := b.value(i, aggregation)
+		minVal = math.Min(minVal, value)
+		maxVal = math.Max(maxVal, value)
+		found = true
+	}
+
+	if !found {
+		return 1.0, 1.0
+	}
+
+	return minVal, maxVal
+}
+
+func signColor(signed, value float64, aggregation ImageAggregation) color.RGBA {
+	base := red
+	if signed < 0 {
+		base = blue
+	}
+
+	if aggregation == DensityAggregation {
+		// Sparse blocks are rendered with lighter colors, but never as light as the zero blocks.
+		return blend(gray, base, 0.3+0.7*value)
+	}
+
+	return base
+}
+
+// normalize maps the value to the [0, 1] range, where 0 corresponds to min and 1 to max.
+func normalize(value, minVal, maxVal float64) float64 {
+	if maxVal <= minVal {
+		return 1.0
+	}
+
+	return (value - minVal) / (maxVal - minVal)
+}
+
+var heatmapStops = []color.RGBA{
+	{68, 1, 84, 255},
+	{59, 82, 139, 255},
+	{33, 145, 140, 255},
+	{94, 201, 98, 255},
+	{253, 231, 37, 255},
+}
+
+// heatmapColor returns the color of the gradient, from dark purple to yellow, at t in [0, 1].
+func heatmapColor(t float64) color.RGBA {
+	t = math.Max(0, math.Min(1, t))
+
+	var (
+		position = t * float64(len(heatmapStops)-1)
+		stop     = int(position)
+	)
+
+	if stop == len(heatmapStops)-1 {
+		return heatmapStops[stop]
+	}
+
+	return blend(heatmapStops[stop], heatmapStops[stop+1], position-float64(stop))
+}
+
+// blend linearly interpolates the from and to colors, where t = 0 is from and t = 1 is to.
+func blend(from, to color.RGBA, t float64) color.RGBA {
+	mix := func(a, b uint8) uint8 {
+		return uint8(math.Round(float64(a) + t*(float64(b)-float64(a))))
+	}
+
+	return color.RGBA{mix(from.R, to.R), mix(from.G, to.G), mix(from.B, to.B), 255}
+}
+
+func fillRect(img *image.RGBA, rect image.Rectangle, c color.RGBA) {
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			img.SetRGBA(x, y, c)
+		}
+	}
 }
@@ -0,0 +1,103 @@
+package mat
+
+import (
+	"image"
+	"math"
+	"strconv"
+)
+
+const (
+	legendMargin    = 10
+	legendBarWidth  = 16
+	legendMinHeight = 100
+	// glyphScale is the number of pixels used to draw each of the glyph pixels.
+	glyphScale   = 2
+	glyphWidth   = 3
+	glyphHeight  = 5
+	glyphAdvance = (glyphWidth + 1) * glyphScale
+)
+
+/*
+glyphs is a tiny bitmap font with the characters needed to write numbers. Each glyph has five
+rows of three pixels, where the most significant of the three bits is the leftmost pixel.
+*/
+var glyphs = map[rune][glyphHeight]uint8{
+	'0': {7, 5, 5, 5, 7},
+	'1': {2, 6, 2, 2, 7},
+	'2': {7, 1, 7, 4, 7},
+	'3': {7, 1, 7, 1, 7},
+	'4': {5, 5, 7, 1, 1},
+	'5': {7, 4, 7, 1, 7},
+	'6': {7, 4, 7, 5, 7},
+	'7': {7, 1, 1, 1, 1},
+	'8': {7, 5, 7, 5, 7},
+	'9': {7, 5, 7, 1, 7},
+	'.': {0, 0, 0, 0, 2},
+	'-': {0, 0, 7, 0, 0},
+	'+': {0, 2, 7, 2, 0},
+	'e': {0, 7, 7, 4, 7},
+}
+
+// legendLabel formats a legend value, which is in logarithmic scale if logScale is true.
+func legendLabel(value float64, logScale bool) string {
+	if logScale {
+		value = math.Pow(10, value)
+	}
+
+	return strconv.FormatFloat(value, 'g', 3, 64)
+}
+
+// legendWidth is the number of pixels the legend adds to the right of the matrix.
+func legendWidth(minVal, maxVal float64, logScale bool) int {
+	chars := len(legendLabel(minVal, logScale))
+	if maxChars := len(legendLabel(maxVal, logScale)); maxChars > chars {
+		chars = maxChars
+	}
+
+	return 2*legendMargin + legendBarWidth + glyphAdvance*(chars+1)
+}
+
+/*
+drawLegend draws a vertical heatmap color bar, starting at the x pixel, with the max value at the
+top and the min value at the bottom.
+*/
+func drawLegend(img *image.RGBA, x int, minVal, maxVal float64, logScale bool) {
+	var (
+		barLeft   = x + legendMargin
+		barTop    = legendMargin
+		barBottom = img.Bounds().Dy() - legendMargin
+		labelLeft = barLeft + legendBarWidth + glyphAdvance
+	)
+
+	for y := barTop; y < barBottom; y++ {
+		t := 1.0 - float64(y-barTop)/float64(barBottom-barTop-1)
+		fillRect(img, image.Rect(barLeft, y, barLeft+legendBarWidth, y+1), heatmapColor(t))
+	}
+
+	drawText(img, labelLeft, barTop, legendLabel(maxVal, logScale))
+	drawText(img, labelLeft, barBottom-glyphHeight*glyphScale, legendLabel(minVal, logScale))
+}
+
+// drawText draws the text in black with its top left corner at the given pixel.
+func drawText(img *image.RGBA, x, y int, text string) {
+	for _, char := range text {
+		glyph := glyphs[char]
+
+		for row, bits := range glyph {
+			for col := 0; col < glyphWidth; col++ {
+				if bits&(1<<(glyphWidth-1-col)) == 0 {
+					continue
+				}
+
+				fillRect(img, image.Rect(
+					x+col*glyphScale,
+					y+row*glyphScale,
+					x+(col+1)*glyphScale,
+					y+(row+1)*glyphScale,
+				), black)
+			}
+		}
+
+		x += glyphAdvance
+	}
+}
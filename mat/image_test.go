@@ -0,0 +1,192 @@
+package mat
+
+import (
+	"bytes"
+	"errors"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRenderImage(t *testing.T) {
+	t.Run("rows are rendered top to bottom", func(t *testing.T) {
+		var (
+			m       = MakeSparseWithData(2, 3, []float64{1, 0, 0, 0, 0, -2})
+			options = DefaultImageOptions()
+		)
+		options.Size = 6
+
+		img, err := Render(m, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if got := img.Bounds().Size(); got.X != 6 || got.Y != 4 {
+			t.Fatalf("Want a 6x4 image, got %dx%d", got.X, got.Y)
+		}
+		assertPixelColor(t, img.RGBAAt(1, 1), red)
+		assertPixelColor(t, img.RGBAAt(5, 3), blue)
+		assertPixelColor(t, img.RGBAAt(5, 0), gray)
+		assertPixelColor(t, img.RGBAAt(0, 3), gray)
+	})
+
+	t.Run("large matrices are downsampled", func(t *testing.T) {
+		var (
+			m       = MakeIdentity(10)
+			options = DefaultImageOptions()
+		)
+		options.Size = 5
+		options.Aggregation = MaxMagnitudeAggregation
+
+		img, err := Render(m, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if got := img.Bounds().Size(); got.X != 5 || got.Y != 5 {
+			t.Fatalf("Want a 5x5 image, got %dx%d", got.X, got.Y)
+		}
+		for i := 0; i < 5; i++ {
+			assertPixelColor(t, img.RGBAAt(i, i), red)
+		}
+		assertPixelColor(t, img.RGBAAt(1, 0), gray)
+	})
+
+	t.Run("density aggregation lightens sparse blocks", func(t *testing.T) {
+		var (
+			m       = MakeSparseWithData(2, 4, []float64{1, 1, 1, 0, 1, 1, 0, 0})
+			options = DefaultImageOptions()
+		)
+		options.Size = 2
+
+		img, err := Render(m, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		assertPixelColor(t, img.RGBAAt(0, 0), red)
+		assertPixelColor(t, img.RGBAAt(1, 0), blend(gray, red, 0.3+0.7*0.25))
+	})
+
+	t.Run("zero tolerance", func(t *testing.T) {
+		var (
+			m       = MakeSparseWithData(1, 2, []float64{1e-3, 1})
+			options = DefaultImageOptions()
+		)
+		options.Size = 2
+		options.ZeroTolerance = 1e-2
+
+		img, err := Render(m, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		assertPixelColor(t, img.RGBAAt(0, 0), gray)
+		assertPixelColor(t, img.RGBAAt(1, 0), red)
+	})
+
+	t.Run("small zero tolerance with dense matrices", func(t *testing.T) {
+		var (
+			m       = MakeDenseWithData(1, 2, []float64{1e-12, 0})
+			options = DefaultImageOptions()
+		)
+		options.Size = 2
+		options.ZeroTolerance = 1e-13
+
+		img, err := Render(m, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		assertPixelColor(t, img.RGBAAt(0, 0), red)
+		assertPixelColor(t, img.RGBAAt(1, 0), gray)
+	})
+
+	t.Run("heatmap with legend", func(t *testing.T) {
+		var (
+			m       = MakeSparseWithData(2, 2, []float64{1, 0, 0, 100})
+			options = DefaultImageOptions()
+		)
+		options.Size = 2
+		options.Aggregation = MaxMagnitudeAggregation
+		options.ColorScale = HeatmapColorScale
+		options.LogScale = true
+		options.Legend = true
+
+		img, err := Render(m, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if got := img.Bounds().Size(); got.X != 2+legendWidth(0, 2, true) || got.Y != legendMinHeight {
+			t.Errorf("Wrong image size with legend: %dx%d", got.X, got.Y)
+		}
+		assertPixelColor(t, img.RGBAAt(0, 0), heatmapStops[0])
+		assertPixelColor(t, img.RGBAAt(1, 1), heatmapStops[len(heatmapStops)-1])
+		assertPixelColor(t, img.RGBAAt(1, 0), gray)
+	})
+
+	t.Run("empty matrix", func(t *testing.T) {
+		if _, err := Render(MakeSparse(0, 0), DefaultImageOptions()); err != ErrEmptyImage {
+			t.Errorf("Want ErrEmptyImage, got %v", err)
+		}
+	})
+}
+
+func TestRenderPNG(t *testing.T) {
+	t.Run("encodes the image", func(t *testing.T) {
+		var (
+			buf     bytes.Buffer
+			options = DefaultImageOptions()
+		)
+		options.Size = 3
+
+		if err := RenderPNG(&buf, MakeIdentity(3), options); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		img, err := png.Decode(&buf)
+		if err != nil {
+			t.Fatalf("Unexpected error decoding the image: %v", err)
+		}
+		if got := img.Bounds().Size(); got.X != 3 || got.Y != 3 {
+			t.Errorf("Want a 3x3 image, got %dx%d", got.X, got.Y)
+		}
+	})
+
+	t.Run("returns the writer errors", func(t *testing.T) {
+		if err := RenderPNG(failingWriter{}, MakeIdentity(3), DefaultImageOptions()); err == nil {
+			t.Error("Expected an error")
+		}
+	})
+}
+
+func TestToImage(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "matrix.png")
+
+	if err := ToImage(MakeIdentity(3), filePath); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := os.Stat(filePath); err != nil {
+		t.Errorf("Expected the image at %s: %v", filePath, err)
+	}
+
+	if err := ToImage(MakeIdentity(3), filepath.Join(filePath, "not-a-dir.png")); err == nil {
+		t.Error("Expected an error creating the file")
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func assertPixelColor(t *testing.T, got, want color.RGBA) {
+	t.Helper()
+	if got != want {
+		t.Errorf("Want pixel color %v, got %v", want, got)
+	}
+}
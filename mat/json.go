@@ -0,0 +1,127 @@
+package mat
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+
+	"github.com/angelsolaorbaiceta/inkmath/nums"
+)
+
+/*
+MarshalJSON encodes the matrix as a JSON array of rows, each of them an array with the row values.
+NaN and infinite values are encoded as the "NaN", "+Inf" and "-Inf" strings.
+
+The number of columns is given by the length of the rows, so matrices without rows but with
+columns can't be encoded: they would be decoded as a 0x0 matrix.
+*/
+func (m DenseMat) MarshalJSON() ([]byte, error) {
+	if m.rows == 0 && m.cols > 0 {
+		return nil, errors.New("mat: can't encode a dense matrix with columns but no rows as JSON")
+	}
+
+	rows := make([][]nums.JSONFloat, m.rows)
+	for i, dataRow := range m.data {
+		rows[i] = make([]nums.JSONFloat, m.cols)
+		for j, val := range dataRow {
+			rows[i][j] = nums.JSONFloat(val)
+		}
+	}
+
+	return json.Marshal(rows)
+}
+
+// UnmarshalJSON decodes a matrix encoded with MarshalJSON, overwriting this matrix.
+func (m *DenseMat) UnmarshalJSON(data []byte) error {
+	var rows [][]nums.JSONFloat
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return err
+	}
+
+	cols := 0
+	if len(rows) > 0 {
+		cols = len(rows[0])
+	}
+
+	matrix := MakeDense(len(rows), cols)
+	for i, row := range rows {
+		if len(row) != cols {
+			return errors.New("mat: all the rows of a dense matrix need the same number of values")
+		}
+
+		for j, val := range row {
+			matrix.data[i][j] = float64(val)
+		}
+	}
+
+	*m = *matrix
+	return nil
+}
+
+type sparseMatJSON struct {
+	Rows     int                 `json:"rows"`
+	Cols     int                 `json:"cols"`
+	Triplets []sparseTripletJSON `json:"triplets"`
+}
+
+type sparseTripletJSON struct {
+	Row   int            `json:"row"`
+	Col   int            `json:"col"`
+	Value nums.JSONFloat `json:"value"`
+}
+
+/*
+MarshalJSON encodes the matrix as a JSON object with its number of rows and columns, and the
+non-zero values as (row, col, value) triplets, sorted by row and column. NaN and infinite values
+are encoded as the "NaN", "+Inf" and "-Inf" strings.
+*/
+func (m SparseMat) MarshalJSON() ([]byte, error) {
+	var (
+		rowIndices = make([]int, 0, len(m.data))
+		encoded    = sparseMatJSON{m.rows, m.cols, make([]sparseTripletJSON, 0)}
+	)
+
+	for row := range m.data {
+		rowIndices = append(rowIndices, row)
+	}
+	sort.Ints(rowIndices)
+
+	for _, row := range rowIndices {
+		cols := m.NonZeroIndicesAtRow(row)
+		sort.Ints(cols)
+
+		for _, col := range cols {
+			encoded.Triplets = append(
+				encoded.Triplets,
+				sparseTripletJSON{row, col, nums.JSONFloat(m.data[row][col])},
+			)
+		}
+	}
+
+	return json.Marshal(encoded)
+}
+
+// UnmarshalJSON decodes a matrix encoded with MarshalJSON, overwriting this matrix.
+func (m *SparseMat) UnmarshalJSON(data []byte) error {
+	var decoded sparseMatJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	if decoded.Rows < 0 || decoded.Cols < 0 {
+		return errors.New("mat: invalid sparse matrix size")
+	}
+
+	matrix := MakeSparse(decoded.Rows, decoded.Cols)
+	for _, triplet := range decoded.Triplets {
+		if triplet.Row < 0 || triplet.Row >= decoded.Rows ||
+			triplet.Col < 0 || triplet.Col >= decoded.Cols {
+			return errors.New("mat: sparse matrix triplet out of bounds")
+		}
+
+		matrix.setValueToAdd(triplet.Row, triplet.Col, float64(triplet.Value))
+	}
+
+	*m = *matrix
+	return nil
+}
@@ -0,0 +1,93 @@
+package mat
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestJSONEncoding(t *testing.T) {
+	data := []float64{1, 0, 2.5, 0, -3, 0}
+
+	t.Run("dense matrix", func(t *testing.T) {
+		want := `[[1,0,2.5],[0,-3,0]]`
+
+		encoded, err := json.Marshal(MakeDenseWithData(2, 3, data))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if string(encoded) != want {
+			t.Errorf("Want %s, got %s", want, encoded)
+		}
+
+		var decoded DenseMat
+		if err := json.Unmarshal(encoded, &decoded); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		assertMatrixContainsData(t, decoded, data)
+	})
+
+	t.Run("sparse matrix", func(t *testing.T) {
+		want := `{"rows":2,"cols":3,"triplets":[` +
+			`{"row":0,"col":0,"value":1},{"row":0,"col":2,"value":2.5},{"row":1,"col":1,"value":-3}]}`
+
+		encoded, err := json.Marshal(MakeSparseWithData(2, 3, data))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if string(encoded) != want {
+			t.Errorf("Want %s, got %s", want, encoded)
+		}
+
+		var decoded SparseMat
+		if err := json.Unmarshal(encoded, &decoded); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		assertMatrixContainsData(t, decoded, data)
+	})
+
+	t.Run("NaN values", func(t *testing.T) {
+		m := MakeDense(1, 1)
+		m.SetValue(0, 0, math.NaN())
+
+		encoded, err := json.Marshal(m)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		var decoded DenseMat
+		if err := json.Unmarshal(encoded, &decoded); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !math.IsNaN(decoded.Value(0, 0)) {
+			t.Errorf("Want NaN, got %f", decoded.Value(0, 0))
+		}
+	})
+
+	t.Run("empty dense matrices", func(t *testing.T) {
+		encoded, err := json.Marshal(MakeDense(3, 0))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		var decoded DenseMat
+		if err := json.Unmarshal(encoded, &decoded); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if decoded.Rows() != 3 || decoded.Cols() != 0 {
+			t.Errorf("Want a 3x0 matrix, got %dx%d", decoded.Rows(), decoded.Cols())
+		}
+
+		if _, err := json.Marshal(MakeDense(0, 3)); err == nil {
+			t.Error("Expected an error encoding a 0x3 matrix")
+		}
+	})
+
+	t.Run("triplets out of bounds", func(t *testing.T) {
+		var decoded SparseMat
+		err := json.Unmarshal([]byte(`{"rows":1,"cols":1,"triplets":[{"row":1,"col":0,"value":1}]}`), &decoded)
+		if err == nil {
+			t.Error("Expected an error")
+		}
+	})
+}
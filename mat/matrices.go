@@ -38,8 +38,15 @@ func IsSquare(m ReadOnlyMatrix) bool {
 /*
 IsSymmetric returns true if the given matrix is square and equals to it's
 traspose.
+
+Matrices which only store one of their triangles, like SymSparseMat, SymDenseMat or
+SkylineMat, are symmetric by construction and don't need their values to be compared.
 */
 func IsSymmetric(m ReadOnlyMatrix) bool {
+	if IsSymmetricByConstruction(m) {
+		return true
+	}
+
 	if !IsSquare(m) {
 		panic("Matrix symmetry only applies to square matrices")
 	}
@@ -55,6 +62,15 @@ func IsSymmetric(m ReadOnlyMatrix) bool {
 	return true
 }
 
+/*
+IsSymmetricByConstruction returns true if the storage of the given matrix makes it symmetric, like
+SymSparseMat, SymDenseMat or SkylineMat, without comparing its values.
+*/
+func IsSymmetricByConstruction(m ReadOnlyMatrix) bool {
+	_, ok := m.(symmetricMatrix)
+	return ok
+}
+
 /*
 IsRowDominant returns true if for every row in the matrix, the element in the
 main diagonal is greater than every other element.
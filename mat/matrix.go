@@ -38,3 +38,12 @@ type MutableMatrix interface {
 	SetZeroCol(int)
 	SetIdentityRow(int)
 }
+
+/*
+A symmetricMatrix is a matrix whose storage makes it symmetric by construction, thus there's no
+need to compare its values to know it's symmetric.
+*/
+type symmetricMatrix interface {
+	ReadOnlyMatrix
+	symmetricStorage()
+}
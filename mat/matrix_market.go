@@ -0,0 +1,366 @@
+package mat
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/*
+A MatrixMarketError is returned when a Matrix Market file can't be read because it's malformed or
+uses a feature which isn't supported.
+*/
+type MatrixMarketError struct {
+	// Line is the number of the line, starting at one, where the error was found.
+	Line int
+	Msg  string
+}
+
+func (err MatrixMarketError) Error() string {
+	return fmt.Sprintf("matrix market: line %d: %s", err.Line, err.Msg)
+}
+
+// maxMatrixMarketReserve is the maximum number of entries reserved before reading them.
+const maxMatrixMarketReserve = 1 << 20
+
+type matrixMarketHeader struct {
+	format, field, symmetry string
+}
+
+/*
+ReadMatrixMarket reads a matrix in Matrix Market (.mtx) format.
+
+Both the coordinate and array formats are supported, with real, integer or pattern fields (pattern
+entries are read as ones) and general, symmetric or skew-symmetric qualifiers. Complex and
+hermitian matrices aren't supported.
+
+Coordinate matrices are read into a SparseMat and array matrices into a DenseMat, unless they are
+symmetric, in which case they are read into a SymSparseMat or a SymDenseMat, respectively.
+
+Any problem reading the file is returned as a MatrixMarketError with the offending line number.
+*/
+func ReadMatrixMarket(r io.Reader) (MutableMatrix, error) {
+	var (
+		scanner = bufio.NewScanner(r)
+		line    = 0
+		fields  []string
+	)
+
+	fail := func(format string, args ...interface{}) (MutableMatrix, error) {
+		return nil, MatrixMarketError{line, fmt.Sprintf(format, args...)}
+	}
+
+	// nextFields returns the fields of the next non-empty, non-comment line.
+	nextFields := func() bool {
+		for scanner.Scan() {
+			line++
+			text := strings.TrimSpace(scanner.Text())
+			if text == "" || strings.HasPrefix(text, "%") {
+				continue
+			}
+
+			fields = strings.Fields(text)
+			return true
+		}
+
+		return false
+	}
+
+	if !scanner.Scan() {
+		line++
+		return fail("missing header")
+	}
+	line++
+
+	header, err := parseMatrixMarketHeader(scanner.Text())
+	if err != nil {
+		return fail("%v", err)
+	}
+
+	if !nextFields() {
+		return fail("missing size line")
+	}
+
+	if header.format == "array" {
+		if len(fields) != 2 {
+			return fail("want 2 values in size line, got %d", len(fields))
+		}
+	} else if len(fields) != 3 {
+		return fail("want 3 values in size line, got %d", len(fields))
+	}
+
+	sizes := make([]int, len(fields))
+	for i, field := range fields {
+		if sizes[i], err = strconv.Atoi(field); err != nil || sizes[i] < 0 {
+			return fail("invalid size %q", field)
+		}
+	}
+
+	var (
+		rows, cols  = sizes[0], sizes[1]
+		isSymmetric = header.symmetry == "symmetric"
+		isSkew      = header.symmetry == "skew-symmetric"
+	)
+
+	if (isSymmetric || isSkew) && rows != cols {
+		return fail("%s matrices must be square", header.symmetry)
+	}
+
+	parseValue := func(field string) (float64, error) {
+		value, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid value %q", field)
+		}
+
+		return value, nil
+	}
+
+	if header.format == "array" {
+		if productOverflows(rows, cols) {
+			return fail("size %dx%d is too large", rows, cols)
+		}
+		// Without columns there are no values to back the number of rows, which is rejected as
+		// creating the matrix would allocate memory for each of them
+		if rows > 0 && cols == 0 {
+			return fail("an array matrix with %d rows needs at least one column", rows)
+		}
+
+		/*
+			Array values are stored column after column. For symmetric matrices only the lower
+			triangle is stored, and for skew-symmetric ones, the diagonal is also skipped. The values
+			are read before creating the matrix, so that its size is backed by the file contents.
+		*/
+		var (
+			values   = make([]float64, 0)
+			startRow = func(col int) int {
+				switch {
+				case isSymmetric:
+					return col
+				case isSkew:
+					return col + 1
+				default:
+					return 0
+				}
+			}
+		)
+
+		for col := 0; col < cols && rows > 0; col++ {
+			for row := startRow(col); row < rows; row++ {
+				if !nextFields() {
+					return fail("unexpected end of file, missing values")
+				}
+				if len(fields) != 1 {
+					return fail("want 1 value per line, got %d", len(fields))
+				}
+
+				value, err := parseValue(fields[0])
+				if err != nil {
+					return fail("%v", err)
+				}
+
+				values = append(values, value)
+			}
+		}
+
+		var matrix MutableMatrix
+		if isSymmetric {
+			matrix = MakeSymDense(rows)
+		} else {
+			matrix = MakeDense(rows, cols)
+		}
+
+		for col, i := 0, 0; col < cols && rows > 0; col++ {
+			for row := startRow(col); row < rows; row, i = row+1, i+1 {
+				matrix.SetValue(row, col, values[i])
+				if isSkew {
+					matrix.SetValue(col, row, -values[i])
+				}
+			}
+		}
+
+		return matrix, nil
+	}
+
+	var (
+		nnz        = sizes[2]
+		builder    = MakeTripletBuilder(rows, cols, 0)
+		symmetric  = MakeSymSparse(rows)
+		wantFields = 3
+		// reserved is the number of entries reserved in advance, which is capped so that a wrong
+		// header doesn't allocate too much memory. The builder grows as needed past it.
+		reserved = nnz
+	)
+
+	if productOverflows(rows, cols) || nnz > rows*cols {
+		return fail("%d entries don't fit in a %dx%d matrix", nnz, rows, cols)
+	}
+
+	if reserved > maxMatrixMarketReserve {
+		reserved = maxMatrixMarketReserve
+	}
+	if isSkew {
+		builder.Reserve(2 * reserved)
+	} else if !isSymmetric {
+		builder.Reserve(reserved)
+	}
+
+	if header.field == "pattern" {
+		wantFields = 2
+	}
+
+	for i := 0; i < nnz; i++ {
+		if !nextFields() {
+			return fail("unexpected end of file, want %d entries, got %d", nnz, i)
+		}
+		if len(fields) != wantFields {
+			return fail("want %d values per entry, got %d", wantFields, len(fields))
+		}
+
+		row, errRow := strconv.Atoi(fields[0])
+		col, errCol := strconv.Atoi(fields[1])
+		if errRow != nil || errCol != nil {
+			return fail("invalid entry indices %q, %q", fields[0], fields[1])
+		}
+		if row < 1 || row > rows || col < 1 || col > cols {
+			return fail("entry (%d, %d) out of the matrix bounds", row, col)
+		}
+		if (isSymmetric && row < col) || (isSkew && row <= col) {
+			return fail("entry (%d, %d) outside the lower triangle", row, col)
+		}
+
+		value := 1.0
+		if wantFields == 3 {
+			if value, err = parseValue(fields[2]); err != nil {
+				return fail("%v", err)
+			}
+		}
+
+		switch {
+		case isSymmetric:
+			symmetric.AddToValue(row-1, col-1, value)
+		case isSkew:
+			builder.Add(row-1, col-1, value)
+			builder.Add(col-1, row-1, -value)
+		default:
+			builder.Add(row-1, col-1, value)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fail("%v", err)
+	}
+
+	if isSymmetric {
+		return symmetric, nil
+	}
+
+	return builder.ToSparse(), nil
+}
+
+// productOverflows returns whether the product of two non-negative integers overflows an int.
+func productOverflows(a, b int) bool {
+	return a != 0 && b > math.MaxInt/a
+}
+
+func parseMatrixMarketHeader(text string) (matrixMarketHeader, error) {
+	fields := strings.Fields(strings.ToLower(text))
+
+	if len(fields) != 5 || fields[0] != "%%matrixmarket" {
+		return matrixMarketHeader{}, fmt.Errorf("invalid header %q", text)
+	}
+	if fields[1] != "matrix" {
+		return matrixMarketHeader{}, fmt.Errorf("unsupported object %q", fields[1])
+	}
+
+	header := matrixMarketHeader{fields[2], fields[3], fields[4]}
+
+	if header.format != "coordinate" && header.format != "array" {
+		return header, fmt.Errorf("unsupported format %q", header.format)
+	}
+	if header.field != "real" && header.field != "integer" && header.field != "pattern" {
+		return header, fmt.Errorf("unsupported field %q", header.field)
+	}
+	if header.field == "pattern" && header.format == "array" {
+		return header, fmt.Errorf("pattern field can't be used with the array format")
+	}
+	if header.symmetry != "general" &&
+		header.symmetry != "symmetric" &&
+		header.symmetry != "skew-symmetric" {
+		return header, fmt.Errorf("unsupported symmetry %q", header.symmetry)
+	}
+
+	return header, nil
+}
+
+/*
+WriteMatrixMarket writes the given matrix in Matrix Market (.mtx) format.
+
+Dense matrices are written using the array format, and every other matrix using the coordinate
+format, where only the non-zero values are written. Matrices which are symmetric by construction
+are written with the symmetric qualifier, storing only their lower triangle.
+*/
+func WriteMatrixMarket(w io.Writer, m ReadOnlyMatrix) error {
+	var (
+		buf          = bufio.NewWriter(w)
+		_, symmetric = m.(symmetricMatrix)
+		symmetry     = "general"
+		rows, cols   = m.Rows(), m.Cols()
+	)
+
+	if symmetric {
+		symmetry = "symmetric"
+	}
+
+	formatValue := func(value float64) string {
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+
+	if isDense(m) {
+		fmt.Fprintf(buf, "%%%%MatrixMarket matrix array real %s\n", symmetry)
+		fmt.Fprintf(buf, "%d %d\n", rows, cols)
+
+		for col := 0; col < cols; col++ {
+			startRow := 0
+			if symmetric {
+				startRow = col
+			}
+
+			for row := startRow; row < rows; row++ {
+				fmt.Fprintln(buf, formatValue(m.Value(row, col)))
+			}
+		}
+
+		return buf.Flush()
+	}
+
+	var (
+		entries = make([][]int, rows)
+		nnz     = 0
+	)
+
+	for row := 0; row < rows; row++ {
+		for _, col := range m.NonZeroIndicesAtRow(row) {
+			if !symmetric || col <= row {
+				entries[row] = append(entries[row], col)
+			}
+		}
+
+		sort.Ints(entries[row])
+		nnz += len(entries[row])
+	}
+
+	fmt.Fprintf(buf, "%%%%MatrixMarket matrix coordinate real %s\n", symmetry)
+	fmt.Fprintf(buf, "%d %d %d\n", rows, cols, nnz)
+
+	for row, rowCols := range entries {
+		for _, col := range rowCols {
+			fmt.Fprintf(buf, "%d %d %s\n", row+1, col+1, formatValue(m.Value(row, col)))
+		}
+	}
+
+	return buf.Flush()
+}
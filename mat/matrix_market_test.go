@@ -0,0 +1,158 @@
+package mat
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReadMatrixMarket(t *testing.T) {
+	t.Run("coordinate general", func(t *testing.T) {
+		file := `%%MatrixMarket matrix coordinate real general
+% A comment
+2 3 4
+1 1 1.5
+2 3 -2
+1 2 3e1
+2 3 1
+`
+		m, err := ReadMatrixMarket(strings.NewReader(file))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		assertMatrixContainsData(t, m, []float64{1.5, 30, 0, 0, 0, -1})
+	})
+
+	t.Run("coordinate symmetric pattern", func(t *testing.T) {
+		file := `%%MatrixMarket matrix coordinate pattern symmetric
+2 2 2
+1 1
+2 1
+`
+		m, err := ReadMatrixMarket(strings.NewReader(file))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if _, ok := m.(*SymSparseMat); !ok {
+			t.Errorf("Want a symmetric sparse matrix, got %T", m)
+		}
+		assertMatrixContainsData(t, m, []float64{1, 1, 1, 0})
+	})
+
+	t.Run("coordinate skew-symmetric", func(t *testing.T) {
+		file := `%%MatrixMarket matrix coordinate integer skew-symmetric
+2 2 1
+2 1 4
+`
+		m, err := ReadMatrixMarket(strings.NewReader(file))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		assertMatrixContainsData(t, m, []float64{0, -4, 4, 0})
+	})
+
+	t.Run("array general", func(t *testing.T) {
+		file := `%%MatrixMarket matrix array real general
+2 2
+1
+2
+3
+4
+`
+		m, err := ReadMatrixMarket(strings.NewReader(file))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		assertMatrixContainsData(t, m, []float64{1, 3, 2, 4})
+	})
+
+	t.Run("errors report the line number", func(t *testing.T) {
+		file := `%%MatrixMarket matrix coordinate real general
+2 2 2
+1 1 1.0
+3 1 2.0
+`
+		_, err := ReadMatrixMarket(strings.NewReader(file))
+
+		var mmErr MatrixMarketError
+		if !errors.As(err, &mmErr) {
+			t.Fatalf("Want a MatrixMarketError, got %v", err)
+		}
+		if mmErr.Line != 4 {
+			t.Errorf("Want error at line 4, got %d", mmErr.Line)
+		}
+	})
+
+	t.Run("sizes in the header aren't trusted", func(t *testing.T) {
+		files := []string{
+			"%%MatrixMarket matrix coordinate real general\n2 2 1000000000000000\n1 1 1.0\n",
+			"%%MatrixMarket matrix coordinate real general\n100000000 100000000 1000000000000000\n1 1 1.0\n",
+			"%%MatrixMarket matrix coordinate real general\n9223372036854775807 3 1\n1 1 1.0\n",
+			"%%MatrixMarket matrix array real general\n100000000 100000000\n1\n2\n",
+			"%%MatrixMarket matrix array real symmetric\n100000000 100000000\n1\n",
+			"%%MatrixMarket matrix array real general\n9223372036854775807 3\n1\n",
+			"%%MatrixMarket matrix array real general\n16777216 0\n",
+		}
+
+		for _, file := range files {
+			_, err := ReadMatrixMarket(strings.NewReader(file))
+
+			var mmErr MatrixMarketError
+			if !errors.As(err, &mmErr) {
+				t.Errorf("Want a MatrixMarketError, got %v", err)
+			}
+		}
+	})
+
+	t.Run("complex matrices aren't supported", func(t *testing.T) {
+		file := "%%MatrixMarket matrix coordinate complex general\n1 1 0\n"
+		if _, err := ReadMatrixMarket(strings.NewReader(file)); err == nil {
+			t.Error("Expected an error")
+		}
+	})
+}
+
+func TestWriteMatrixMarket(t *testing.T) {
+	roundTrip := func(m ReadOnlyMatrix) ReadOnlyMatrix {
+		var buf bytes.Buffer
+		if err := WriteMatrixMarket(&buf, m); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		read, err := ReadMatrixMarket(&buf)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		return read
+	}
+
+	data := []float64{1, 0, 2.5, 0, -3, 0}
+
+	t.Run("sparse matrix", func(t *testing.T) {
+		assertMatrixContainsData(t, roundTrip(MakeSparseWithData(2, 3, data)), data)
+	})
+
+	t.Run("dense matrix", func(t *testing.T) {
+		assertMatrixContainsData(t, roundTrip(MakeDenseWithData(2, 3, data)), data)
+	})
+
+	t.Run("symmetric matrix", func(t *testing.T) {
+		var (
+			symData = []float64{1, 2, 2, 5}
+			m       = MakeSymSparse(2)
+		)
+		FillMatrixWithData(m, symData)
+
+		got := roundTrip(m)
+		if _, ok := got.(*SymSparseMat); !ok {
+			t.Errorf("Want a symmetric sparse matrix, got %T", got)
+		}
+		assertMatrixContainsData(t, got, symData)
+	})
+}
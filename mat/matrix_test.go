@@ -205,3 +205,19 @@ func assertMatrixContainsData(t *testing.T, got ReadOnlyMatrix, wantData []float
 		t.Errorf("Matrix contains wrong data. Want %v, got %v", wantData, got)
 	}
 }
+
+func assertIndices(t *testing.T, got, want []int) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Errorf("Want indices %v, got %v", want, got)
+		return
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Want indices %v, got %v", want, got)
+			return
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package mat
+
+import "math"
+
+/*
+NormOne returns the 1-norm of the matrix: the maximum absolute column sum.
+
+Only the non-zero values of each row are visited, so for sparse matrices the cost is proportional
+to the number of non-zero values. Every stored value is considered, however small.
+*/
+func NormOne(m ReadOnlyMatrix) float64 {
+	var (
+		colSums = make([]float64, m.Cols())
+		norm    = 0.0
+	)
+
+	for row := 0; row < m.Rows(); row++ {
+		ForEachNonZeroInRow(m, row, func(col int, value float64) {
+			colSums[col] += math.Abs(value)
+		})
+	}
+
+	for _, sum := range colSums {
+		norm = math.Max(norm, sum)
+	}
+
+	return norm
+}
+
+// NormInf returns the infinity norm of the matrix: the maximum absolute row sum.
+func NormInf(m ReadOnlyMatrix) float64 {
+	norm := 0.0
+
+	for row := 0; row < m.Rows(); row++ {
+		sum := 0.0
+		ForEachNonZeroInRow(m, row, func(_ int, value float64) {
+			sum += math.Abs(value)
+		})
+
+		norm = math.Max(norm, sum)
+	}
+
+	return norm
+}
+
+/*
+NormFrobenius returns the Frobenius norm of the matrix: the square root of the sum of its squared
+values. The sum is scaled as it's computed to avoid overflows and underflows.
+*/
+func NormFrobenius(m ReadOnlyMatrix) float64 {
+	var (
+		scale = 0.0
+		sumSq = 1.0
+	)
+
+	for row := 0; row < m.Rows(); row++ {
+		ForEachNonZeroInRow(m, row, func(_ int, value float64) {
+			value = math.Abs(value)
+			if value == 0.0 {
+				return
+			}
+
+			if scale < value {
+				sumSq = 1.0 + sumSq*(scale/value)*(scale/value)
+				scale = value
+			} else {
+				sumSq += (value / scale) * (value / scale)
+			}
+		})
+	}
+
+	return scale * math.Sqrt(sumSq)
+}
+
+// NormMax returns the largest absolute value in the matrix.
+func NormMax(m ReadOnlyMatrix) float64 {
+	norm := 0.0
+
+	for row := 0; row < m.Rows(); row++ {
+		ForEachNonZeroInRow(m, row, func(_ int, value float64) {
+			norm = math.Max(norm, math.Abs(value))
+		})
+	}
+
+	return norm
+}
@@ -0,0 +1,66 @@
+package mat
+
+import (
+	"math"
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkmath/nums"
+)
+
+func TestMatrixNorms(t *testing.T) {
+	data := []float64{
+		1, -7, 0,
+		-2, 0, 3,
+	}
+
+	for _, m := range []ReadOnlyMatrix{MakeSparseWithData(2, 3, data), MakeDenseWithData(2, 3, data)} {
+		if got := NormOne(m); got != 7.0 {
+			t.Errorf("Want 1-norm 7, got %f", got)
+		}
+		if got := NormInf(m); got != 8.0 {
+			t.Errorf("Want infinity norm 8, got %f", got)
+		}
+		if got, want := NormFrobenius(m), math.Sqrt(63); !nums.FloatsEqual(got, want) {
+			t.Errorf("Want Frobenius norm %f, got %f", want, got)
+		}
+		if got := NormMax(m); got != 7.0 {
+			t.Errorf("Want max norm 7, got %f", got)
+		}
+	}
+
+	t.Run("Frobenius norm doesn't overflow", func(t *testing.T) {
+		m := MakeSparseWithData(1, 2, []float64{3e200, 4e200})
+		if got := NormFrobenius(m); !nums.FloatsEqualEps(got/5e200, 1.0, 1e-12) {
+			t.Errorf("Want Frobenius norm 5e200, got %g", got)
+		}
+	})
+
+	t.Run("zero matrix", func(t *testing.T) {
+		m := MakeSparse(3, 3)
+		if NormOne(m) != 0 || NormInf(m) != 0 || NormFrobenius(m) != 0 || NormMax(m) != 0 {
+			t.Error("Want zero norms")
+		}
+	})
+
+	t.Run("small dense values aren't dropped", func(t *testing.T) {
+		var (
+			dense    = MakeDenseWithData(2, 2, []float64{1e-11, 0, 0, 1e-11})
+			symDense = MakeSymDense(2)
+		)
+		FillMatrixWithData(symDense, []float64{1e-11, 0, 0, 1e-11})
+
+		for _, m := range []ReadOnlyMatrix{dense, symDense, MakeView(dense, []int{0, 1}, []int{0, 1})} {
+			if NormOne(m) != 1e-11 || NormInf(m) != 1e-11 || NormMax(m) != 1e-11 {
+				t.Errorf("Want norms 1e-11, got %g, %g and %g", NormOne(m), NormInf(m), NormMax(m))
+			}
+			if got, want := NormFrobenius(m), 1e-11*math.Sqrt(2); !nums.FloatsEqualEps(got/want, 1.0, 1e-12) {
+				t.Errorf("Want Frobenius norm %g, got %g", want, got)
+			}
+		}
+
+		// A scaled identity is perfectly conditioned
+		if got := EstimateCondOne(dense, MakeLowerTriangular(dense, false)); !nums.FloatsEqual(got, 1.0) {
+			t.Errorf("Want condition number 1, got %g", got)
+		}
+	})
+}
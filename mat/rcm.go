@@ -0,0 +1,265 @@
+package mat
+
+import "sort"
+
+/*
+An Ordering is a renumbering of the rows and columns of a symmetric matrix, together with the
+bandwidth and profile of the matrix before and after applying it.
+*/
+type Ordering struct {
+	// Permutation has, for each new index, the old index: Permutation[new] = old.
+	Permutation     []int
+	BandwidthBefore int
+	BandwidthAfter  int
+	ProfileBefore   int
+	ProfileAfter    int
+}
+
+/*
+ReverseCuthillMcKee computes the Reverse Cuthill-McKee ordering of a symmetric matrix, which
+reduces its bandwidth and profile, and thus the fill-in of its factorization and the cost of
+iterative solvers.
+
+The ordering is computed from the sparsity graph of the matrix, where two rows are connected if
+there's a non-zero value in their intersection. The pattern is symmetrized, so only the positions
+of the non-zero values need to be symmetric. Each connected component of the graph is numbered
+with a breadth-first search starting from a pseudo-peripheral node, found with the George-Liu
+algorithm, and visiting the neighbors by increasing degree. The resulting order is then reversed.
+
+Use PermuteSymmetric to apply the ordering to the matrix, and vec.Permute and vec.Unpermute to
+apply it to the vectors of a system of equations and its solution.
+*/
+func ReverseCuthillMcKee(m ReadOnlyMatrix) *Ordering {
+	if !IsSquare(m) {
+		panic("Can't compute the ordering of a non-square matrix")
+	}
+
+	var (
+		size        = m.Rows()
+		graph       = makeSparsityGraph(m)
+		visited     = make([]bool, size)
+		permutation = make([]int, 0, size)
+		byDegree    = make([]int, size)
+	)
+
+	for i := range byDegree {
+		byDegree[i] = i
+	}
+	sort.SliceStable(byDegree, func(i, j int) bool {
+		return len(graph[byDegree[i]]) < len(graph[byDegree[j]])
+	})
+
+	for _, seed := range byDegree {
+		if visited[seed] {
+			continue
+		}
+
+		var (
+			root  = graph.pseudoPeripheralNode(seed)
+			start = len(permutation)
+		)
+
+		visited[root] = true
+		permutation = append(permutation, root)
+
+		for next := start; next < len(permutation); next++ {
+			neighbors := make([]int, 0, len(graph[permutation[next]]))
+			for _, neighbor := range graph[permutation[next]] {
+				if !visited[neighbor] {
+					visited[neighbor] = true
+					neighbors = append(neighbors, neighbor)
+				}
+			}
+
+			sort.SliceStable(neighbors, func(i, j int) bool {
+				return len(graph[neighbors[i]]) < len(graph[neighbors[j]])
+			})
+			permutation = append(permutation, neighbors...)
+		}
+	}
+
+	for i, j := 0, size-1; i < j; i, j = i+1, j-1 {
+		permutation[i], permutation[j] = permutation[j], permutation[i]
+	}
+
+	permuted := MakeView(m, permutation, permutation)
+
+	return &Ordering{
+		Permutation:     permutation,
+		BandwidthBefore: Bandwidth(m),
+		BandwidthAfter:  Bandwidth(permuted),
+		ProfileBefore:   Profile(m),
+		ProfileAfter:    Profile(permuted),
+	}
+}
+
+// A sparsityGraph has, for each row of a matrix, the other rows it's connected to, sorted.
+type sparsityGraph [][]int
+
+func makeSparsityGraph(m ReadOnlyMatrix) sparsityGraph {
+	var (
+		size      = m.Rows()
+		neighbors = make([]map[int]bool, size)
+		graph     = make(sparsityGraph, size)
+	)
+
+	for i := range neighbors {
+		neighbors[i] = make(map[int]bool)
+	}
+
+	for row := 0; row < size; row++ {
+		for _, col := range m.NonZeroIndicesAtRow(row) {
+			if col != row {
+				neighbors[row][col] = true
+				neighbors[col][row] = true
+			}
+		}
+	}
+
+	for i, nodes := range neighbors {
+		graph[i] = make([]int, 0, len(nodes))
+		for node := range nodes {
+			graph[i] = append(graph[i], node)
+		}
+
+		sort.Ints(graph[i])
+	}
+
+	return graph
+}
+
+/*
+levels returns the level structure rooted at the given node: the nodes at each distance from the
+root, in its connected component.
+*/
+func (g sparsityGraph) levels(root int) [][]int {
+	var (
+		visited = map[int]bool{root: true}
+		levels  = [][]int{{root}}
+	)
+
+	for {
+		next := make([]int, 0)
+		for _, node := range levels[len(levels)-1] {
+			for _, neighbor := range g[node] {
+				if !visited[neighbor] {
+					visited[neighbor] = true
+					next = append(next, neighbor)
+				}
+			}
+		}
+
+		if len(next) == 0 {
+			return levels
+		}
+
+		levels = append(levels, next)
+	}
+}
+
+/*
+pseudoPeripheralNode finds a node, in the connected component of the start node, whose level
+structure is as deep as possible, using the George-Liu algorithm.
+*/
+func (g sparsityGraph) pseudoPeripheralNode(start int) int {
+	var (
+		root   = start
+		levels = g.levels(root)
+	)
+
+	for {
+		var (
+			lastLevel = levels[len(levels)-1]
+			candidate = lastLevel[0]
+		)
+
+		for _, node := range lastLevel[1:] {
+			if len(g[node]) < len(g[candidate]) {
+				candidate = node
+			}
+		}
+
+		candidateLevels := g.levels(candidate)
+		if len(candidateLevels) <= len(levels) {
+			return root
+		}
+
+		root, levels = candidate, candidateLevels
+	}
+}
+
+// Bandwidth returns the largest distance between a non-zero value and the main diagonal.
+func Bandwidth(m ReadOnlyMatrix) int {
+	bandwidth := 0
+
+	for row := 0; row < m.Rows(); row++ {
+		for _, col := range m.NonZeroIndicesAtRow(row) {
+			distance := row - col
+			if distance < 0 {
+				distance = -distance
+			}
+
+			if distance > bandwidth {
+				bandwidth = distance
+			}
+		}
+	}
+
+	return bandwidth
+}
+
+/*
+Profile returns the size of the envelope of the lower triangle of the matrix: the sum, for every
+row, of the distance between its first non-zero value and the main diagonal. For symmetric
+matrices, it's the number of values stored off the diagonal in a SkylineMat.
+*/
+func Profile(m ReadOnlyMatrix) int {
+	profile := 0
+
+	for row := 0; row < m.Rows(); row++ {
+		first := row
+		for _, col := range m.NonZeroIndicesAtRow(row) {
+			if col < first {
+				first = col
+			}
+		}
+
+		profile += row - first
+	}
+
+	return profile
+}
+
+/*
+PermuteSymmetric applies the permutation to both the rows and columns of the matrix, returning a
+new sparse matrix P A Pᵀ, where the value at (i, j) is the value of A at
+(permutation[i], permutation[j]).
+*/
+func PermuteSymmetric(m ReadOnlyMatrix, permutation []int) *SparseMat {
+	if !IsSquare(m) || len(permutation) != m.Rows() {
+		panic("Can't permute the matrix due to size mismatch")
+	}
+
+	return CopyToSparse(MakeView(m, permutation, permutation))
+}
+
+/*
+InversePermutation returns the inverse of the permutation, which has, for each old index, the
+new index.
+*/
+func InversePermutation(permutation []int) []int {
+	inverse := make([]int, len(permutation))
+	for i := range inverse {
+		inverse[i] = -1
+	}
+
+	for newIndex, oldIndex := range permutation {
+		if oldIndex < 0 || oldIndex >= len(permutation) || inverse[oldIndex] >= 0 {
+			panic("Invalid permutation")
+		}
+
+		inverse[oldIndex] = newIndex
+	}
+
+	return inverse
+}
@@ -0,0 +1,124 @@
+package mat
+
+import (
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+func TestReverseCuthillMcKee(t *testing.T) {
+	t.Run("reduces the bandwidth of a scrambled path", func(t *testing.T) {
+		// Path graph 0 - 4 - 1 - 3 - 2 - 5, numbered in a scattered way
+		var (
+			path = []int{0, 4, 1, 3, 2, 5}
+			m    = MakeSymSparse(6)
+		)
+
+		for i, node := range path {
+			m.SetValue(node, node, 2.0)
+			if i > 0 {
+				m.SetValue(node, path[i-1], -1.0)
+			}
+		}
+
+		ordering := ReverseCuthillMcKee(m)
+
+		assertPermutation(t, ordering.Permutation)
+		if ordering.BandwidthBefore != 4 || ordering.BandwidthAfter != 1 {
+			t.Errorf(
+				"Want bandwidth from 4 to 1, got from %d to %d",
+				ordering.BandwidthBefore,
+				ordering.BandwidthAfter,
+			)
+		}
+		if ordering.ProfileBefore != 9 || ordering.ProfileAfter != 5 {
+			t.Errorf(
+				"Want profile from 9 to 5, got from %d to %d",
+				ordering.ProfileBefore,
+				ordering.ProfileAfter,
+			)
+		}
+
+		// The path is numbered from one of its ends
+		if first := ordering.Permutation[0]; first != 0 && first != 5 {
+			t.Errorf("Expected the ordering to start at an end of the path, got %d", first)
+		}
+	})
+
+	t.Run("disconnected components", func(t *testing.T) {
+		m := MakeSparseWithData(4, 4, []float64{
+			1, 0, 0, 1,
+			0, 1, 0, 0,
+			0, 0, 1, 0,
+			1, 0, 0, 1,
+		})
+
+		ordering := ReverseCuthillMcKee(m)
+
+		assertPermutation(t, ordering.Permutation)
+		if ordering.BandwidthAfter != 1 {
+			t.Errorf("Want bandwidth 1, got %d", ordering.BandwidthAfter)
+		}
+	})
+}
+
+func TestPermuteSymmetric(t *testing.T) {
+	var (
+		m = MakeSparseWithData(3, 3, []float64{
+			1, 2, 0,
+			2, 3, 4,
+			0, 4, 5,
+		})
+		permutation = []int{2, 0, 1}
+		permuted    = PermuteSymmetric(m, permutation)
+	)
+
+	assertMatrixContainsData(t, permuted, []float64{
+		5, 0, 4,
+		0, 1, 2,
+		4, 2, 3,
+	})
+
+	// Solving the permuted system gives the permuted solution
+	var (
+		x  = vec.MakeWithValues([]float64{1, 2, 3})
+		b  = m.TimesVector(x)
+		pb = vec.Permute(b, permutation)
+	)
+
+	if got := permuted.TimesVector(vec.Permute(x, permutation)); !got.Equals(pb) {
+		t.Errorf("Want %v, got %v", pb, got)
+	}
+
+	inverse := InversePermutation(permutation)
+	assertIndices(t, inverse, []int{1, 2, 0})
+}
+
+func TestBandwidthAndProfile(t *testing.T) {
+	m := MakeSparseWithData(4, 4, []float64{
+		1, 0, 0, 0,
+		1, 1, 0, 0,
+		1, 0, 1, 0,
+		0, 0, 1, 1,
+	})
+
+	if got := Bandwidth(m); got != 2 {
+		t.Errorf("Want bandwidth 2, got %d", got)
+	}
+	if got := Profile(m); got != 4 {
+		t.Errorf("Want profile 4, got %d", got)
+	}
+}
+
+func assertPermutation(t *testing.T, permutation []int) {
+	t.Helper()
+
+	seen := make(map[int]bool)
+	for _, index := range permutation {
+		if index < 0 || index >= len(permutation) || seen[index] {
+			t.Fatalf("Invalid permutation: %v", permutation)
+		}
+
+		seen[index] = true
+	}
+}
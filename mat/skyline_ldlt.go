@@ -0,0 +1,129 @@
+package mat
+
+import (
+	"math"
+
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+// pivotTolerance is the relative size of a pivot, with respect to the original diagonal value,
+// below which the pivot is considered zero.
+const pivotTolerance = 1e-14
+
+/*
+A SkylineLDLT is the LDLᵀ factorization of a symmetric skyline matrix, where L is a unit lower
+triangular matrix and D a diagonal matrix.
+
+The factors are stored in the same profile as the original matrix: the upper triangle holds
+Lᵀ (whose diagonal is implicitly one) and the main diagonal holds D.
+*/
+type SkylineLDLT struct {
+	factors *SkylineMat
+}
+
+/*
+FactorizeLDLT computes the LDLᵀ factorization of this matrix in place.
+
+The values of the matrix are overwritten with the factors, so the matrix shouldn't be used after
+this call. Use Clone to keep a copy of the original matrix if it's still needed.
+
+A ZeroPivotError is returned if the matrix is singular.
+*/
+func (m *SkylineMat) FactorizeLDLT() (*SkylineLDLT, error) {
+	var (
+		values           = m.values
+		firstRow         = m.firstRow
+		offsetI, offsetJ int
+		start            int
+		sum, g, diag     float64
+	)
+
+	for j := 0; j < m.size; j++ {
+		offsetJ = m.colStart[j] - firstRow[j]
+
+		// Reduce the column: g_ij = a_ij - Σ l_ki g_kj
+		for i := firstRow[j] + 1; i < j; i++ {
+			offsetI = m.colStart[i] - firstRow[i]
+			start = firstRow[j]
+			if firstRow[i] > start {
+				start = firstRow[i]
+			}
+
+			sum = 0.0
+			for k := start; k < i; k++ {
+				sum += values[offsetI+k] * values[offsetJ+k]
+			}
+
+			values[offsetJ+i] -= sum
+		}
+
+		// Scale the column by the pivots: l_ij = g_ij / d_i, and d_j = a_jj - Σ l_ij g_ij
+		diag = values[offsetJ+j]
+		for i := firstRow[j]; i < j; i++ {
+			g = values[offsetJ+i]
+			values[offsetJ+i] = g / values[m.colStart[i+1]-1]
+			values[offsetJ+j] -= values[offsetJ+i] * g
+		}
+
+		if math.Abs(values[offsetJ+j]) <= pivotTolerance*math.Abs(diag) {
+			return nil, ZeroPivotError{j}
+		}
+	}
+
+	return &SkylineLDLT{m}, nil
+}
+
+// Size returns the number of rows (and columns) of the factorized matrix.
+func (f SkylineLDLT) Size() int {
+	return f.factors.size
+}
+
+// Solve solves the system A x = b using the factorization of A.
+func (f SkylineLDLT) Solve(b vec.ReadOnlyVector) vec.ReadOnlyVector {
+	var (
+		m      = f.factors
+		x      = b.Clone().AsMutable()
+		offset int
+		sum    float64
+	)
+
+	if m.size != b.Length() {
+		panic("Can't solve system due to size mismatch")
+	}
+
+	// Forward substitution: L y = b
+	for j := 0; j < m.size; j++ {
+		offset = m.colStart[j] - m.firstRow[j]
+
+		sum = 0.0
+		for i := m.firstRow[j]; i < j; i++ {
+			sum += m.values[offset+i] * x.Value(i)
+		}
+
+		x.SetValue(j, x.Value(j)-sum)
+	}
+
+	// Diagonal scaling: D z = y
+	for j := 0; j < m.size; j++ {
+		x.SetValue(j, x.Value(j)/m.values[m.colStart[j+1]-1])
+	}
+
+	// Backward substitution: Lᵀ x = z
+	for j := m.size - 1; j > 0; j-- {
+		offset = m.colStart[j] - m.firstRow[j]
+
+		for i := m.firstRow[j]; i < j; i++ {
+			x.SetValue(i, x.Value(i)-m.values[offset+i]*x.Value(j))
+		}
+	}
+
+	return x
+}
+
+/*
+SolveTransposed solves the system Aᵀ x = b using the factorization of A. As A is symmetric, this
+is the same as Solve.
+*/
+func (f SkylineLDLT) SolveTransposed(b vec.ReadOnlyVector) vec.ReadOnlyVector {
+	return f.Solve(b)
+}
@@ -0,0 +1,219 @@
+package mat
+
+import (
+	"github.com/angelsolaorbaiceta/inkmath/nums"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+/*
+A SkylineMat is a symmetric matrix stored in skyline (or profile) format.
+
+For every column, only the values between the first non-zero row and the main diagonal are
+stored, that is, the upper envelope of the column. The values in the lower triangle are the
+mirrored values of the upper one.
+
+Banded matrices with a variable band, like the stiffness matrices of structures after a good
+renumbering of their degrees of freedom, are stored efficiently in this format. The profile
+of the matrix doesn't grow when it's factorized, so the LDLᵀ factorization can be computed
+in place.
+*/
+type SkylineMat struct {
+	size int
+	// firstRow[j] is the row index of the first stored value in the column j.
+	firstRow []int
+	// colStart[j] is the index in values of the first stored value in the column j.
+	colStart []int
+	values   []float64
+}
+
+/*
+MakeSkyline creates a new symmetric skyline matrix of the given size with all values set to
+zero. Only the main diagonal is stored initially: the profile grows as values are set.
+*/
+func MakeSkyline(size int) *SkylineMat {
+	firstRow := make([]int, size)
+	for j := 0; j < size; j++ {
+		firstRow[j] = j
+	}
+
+	return makeSkylineWithProfile(firstRow)
+}
+
+/*
+MakeSkylineFromMatrix creates a new skyline matrix with the values and sparsity pattern of the
+given symmetric matrix.
+
+Only the upper triangle of the given matrix is read, but the sparsity pattern of both triangles
+is used to compute the profile.
+*/
+func MakeSkylineFromMatrix(m ReadOnlyMatrix) *SkylineMat {
+	if !IsSquare(m) {
+		panic("Skyline matrices are symmetric, thus need to be square")
+	}
+
+	var (
+		size     = m.Rows()
+		firstRow = make([]int, size)
+	)
+
+	for j := 0; j < size; j++ {
+		firstRow[j] = j
+	}
+
+	for i := 0; i < size; i++ {
+		for _, j := range m.NonZeroIndicesAtRow(i) {
+			if i < j && i < firstRow[j] {
+				firstRow[j] = i
+			} else if j < i && j < firstRow[i] {
+				firstRow[i] = j
+			}
+		}
+	}
+
+	skyline := makeSkylineWithProfile(firstRow)
+
+	for j := 0; j < size; j++ {
+		for i := firstRow[j]; i <= j; i++ {
+			skyline.values[skyline.colStart[j]+i-firstRow[j]] = m.Value(i, j)
+		}
+	}
+
+	return skyline
+}
+
+func makeSkylineWithProfile(firstRow []int) *SkylineMat {
+	var (
+		size     = len(firstRow)
+		colStart = make([]int, size+1)
+	)
+
+	for j := 0; j < size; j++ {
+		colStart[j+1] = colStart[j] + j - firstRow[j] + 1
+	}
+
+	return &SkylineMat{size, firstRow, colStart, make([]float64, colStart[size])}
+}
+
+// Clone creates a copy of this matrix with the same profile and values.
+func (m SkylineMat) Clone() *SkylineMat {
+	clone := &SkylineMat{
+		size:     m.size,
+		firstRow: make([]int, len(m.firstRow)),
+		colStart: make([]int, len(m.colStart)),
+		values:   make([]float64, len(m.values)),
+	}
+
+	copy(clone.firstRow, m.firstRow)
+	copy(clone.colStart, m.colStart)
+	copy(clone.values, m.values)
+
+	return clone
+}
+
+func (m SkylineMat) symmetricStorage() {}
+
+// Rows returns the number of rows in the matrix.
+func (m SkylineMat) Rows() int { return m.size }
+
+// Cols returns the number of columns in the matrix.
+func (m SkylineMat) Cols() int { return m.size }
+
+/*
+ProfileSize returns the number of values stored in the profile of the matrix, including the
+zeroes that lay inside of it.
+*/
+func (m SkylineMat) ProfileSize() int { return len(m.values) }
+
+// Value returns the value at a given row and column.
+func (m SkylineMat) Value(row, col int) float64 {
+	if row > col {
+		row, col = col, row
+	}
+
+	if row < m.firstRow[col] {
+		return 0.0
+	}
+
+	return m.values[m.colStart[col]+row-m.firstRow[col]]
+}
+
+// NonZeroIndicesAtRow returns a slice with all non-zero elements indices for the given row.
+func (m SkylineMat) NonZeroIndicesAtRow(row int) []int {
+	indices := make([]int, 0)
+
+	// Lower triangle and diagonal: the upper envelope of the column with the same index
+	for i := m.firstRow[row]; i <= row; i++ {
+		if !nums.IsCloseToZero(m.values[m.colStart[row]+i-m.firstRow[row]]) {
+			indices = append(indices, i)
+		}
+	}
+
+	// Upper triangle: the columns whose envelope reaches the row
+	for col := row + 1; col < m.size; col++ {
+		if m.firstRow[col] <= row && !nums.IsCloseToZero(m.Value(row, col)) {
+			indices = append(indices, col)
+		}
+	}
+
+	return indices
+}
+
+// TimesVector multiplies this matrix and a vector.
+func (m SkylineMat) TimesVector(v vec.ReadOnlyVector) vec.ReadOnlyVector {
+	if m.size != v.Length() {
+		panic("Can't multiply matrix and vector due to size mismatch")
+	}
+
+	var (
+		result = vec.Make(m.size)
+		val    float64
+		offset int
+	)
+
+	for j := 0; j < m.size; j++ {
+		offset = m.colStart[j] - m.firstRow[j]
+
+		for i := m.firstRow[j]; i < j; i++ {
+			val = m.values[offset+i]
+			result.SetValue(i, result.Value(i)+val*v.Value(j))
+			result.SetValue(j, result.Value(j)+val*v.Value(i))
+		}
+
+		result.SetValue(j, result.Value(j)+m.values[offset+j]*v.Value(j))
+	}
+
+	return result
+}
+
+// TimesMatrix multiplies this matrix times other.
+func (m SkylineMat) TimesMatrix(other ReadOnlyMatrix) ReadOnlyMatrix {
+	if m.size != other.Rows() {
+		panic("Can't multiply matrices due to size mismatch")
+	}
+
+	return sparseTimesMatrix(m, other)
+}
+
+// RowTimesVector returns the result of multiplying the row at the given index times the given vector.
+func (m SkylineMat) RowTimesVector(row int, v vec.ReadOnlyVector) float64 {
+	if m.size != v.Length() {
+		panic("Can't multiply matrix row with vector due to size mismatch")
+	}
+
+	var (
+		offset = m.colStart[row] - m.firstRow[row]
+		result = 0.0
+	)
+
+	for i := m.firstRow[row]; i <= row; i++ {
+		result += m.values[offset+i] * v.Value(i)
+	}
+
+	for col := row + 1; col < m.size; col++ {
+		if m.firstRow[col] <= row {
+			result += m.Value(row, col) * v.Value(col)
+		}
+	}
+
+	return result
+}
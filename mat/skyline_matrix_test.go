@@ -0,0 +1,118 @@
+package mat
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+var skylineTestData = []float64{
+	4, -2, 0, 2,
+	-2, 10, -2, -7,
+	0, -2, 8, 0,
+	2, -7, 0, 7,
+}
+
+func TestSkylineMatrix(t *testing.T) {
+	skyline := MakeSkylineFromMatrix(MakeSparseWithData(4, 4, skylineTestData))
+
+	t.Run("contains the same data as the original matrix", func(t *testing.T) {
+		assertMatrixContainsData(t, skyline, skylineTestData)
+	})
+
+	t.Run("stores the upper envelope of each column", func(t *testing.T) {
+		// Column heights: 1, 2, 2, 4
+		if got := skyline.ProfileSize(); got != 9 {
+			t.Errorf("Want a profile of 9 values, got %d", got)
+		}
+	})
+
+	t.Run("is recognized as symmetric", func(t *testing.T) {
+		if !IsSymmetric(skyline) {
+			t.Error("Expected skyline matrix to be symmetric")
+		}
+	})
+
+	t.Run("non-zero indices at row", func(t *testing.T) {
+		assertIndices(t, skyline.NonZeroIndicesAtRow(1), []int{0, 1, 2, 3})
+		assertIndices(t, skyline.NonZeroIndicesAtRow(2), []int{1, 2})
+	})
+
+	t.Run("times vector", func(t *testing.T) {
+		var (
+			v    = vec.MakeWithValues([]float64{1, 2, 3, 4})
+			want = []float64{8, -16, 20, 16}
+		)
+
+		if got := skyline.TimesVector(v); !vec.VectorContainsData(got, want) {
+			t.Errorf("Want %v, got %v", want, got)
+		}
+		if got := skyline.RowTimesVector(1, v); got != -16 {
+			t.Errorf("Want -16, got %f", got)
+		}
+	})
+}
+
+func TestSkylineMutableMatrix(t *testing.T) {
+	t.Run("setting a value outside the profile grows it", func(t *testing.T) {
+		skyline := MakeSkyline(3)
+		skyline.SetValue(0, 0, 1)
+		skyline.SetValue(2, 0, 5)
+		skyline.AddToValue(1, 2, 3)
+
+		assertMatrixContainsData(t, skyline, []float64{
+			1, 0, 5,
+			0, 0, 3,
+			5, 3, 0,
+		})
+		if got := skyline.ProfileSize(); got != 5 {
+			t.Errorf("Want a profile of 5 values, got %d", got)
+		}
+	})
+
+	t.Run("set identity row keeps symmetry", func(t *testing.T) {
+		skyline := MakeSkylineFromMatrix(MakeSparseWithData(4, 4, skylineTestData))
+		skyline.SetIdentityRow(1)
+
+		assertMatrixContainsData(t, skyline, []float64{
+			4, 0, 0, 2,
+			0, 1, 0, 0,
+			0, 0, 8, 0,
+			2, 0, 0, 7,
+		})
+	})
+}
+
+func TestSkylineLDLT(t *testing.T) {
+	t.Run("solves a system", func(t *testing.T) {
+		var (
+			skyline = MakeSkylineFromMatrix(MakeSparseWithData(4, 4, skylineTestData))
+			b       = vec.MakeWithValues([]float64{8, -16, 20, 16})
+			want    = []float64{1, 2, 3, 4}
+		)
+
+		ldlt, err := skyline.FactorizeLDLT()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if got := ldlt.Solve(b); !vec.VectorContainsData(got, want) {
+			t.Errorf("Want %v, got %v", want, got)
+		}
+	})
+
+	t.Run("fails with a singular matrix", func(t *testing.T) {
+		skyline := MakeSkylineFromMatrix(MakeSparseWithData(2, 2, []float64{1, 2, 2, 4}))
+
+		_, err := skyline.FactorizeLDLT()
+
+		var pivotErr ZeroPivotError
+		if !errors.As(err, &pivotErr) {
+			t.Fatalf("Want a ZeroPivotError, got %v", err)
+		}
+		if pivotErr.Index != 1 {
+			t.Errorf("Want zero pivot at index 1, got %d", pivotErr.Index)
+		}
+	})
+}
@@ -0,0 +1,92 @@
+package mat
+
+import "github.com/angelsolaorbaiceta/inkmath/nums"
+
+/*
+SetValue sets a value for a given row and column, and its symmetric position.
+
+If the position lays outside of the profile of the matrix, the profile grows to include it.
+*/
+func (m *SkylineMat) SetValue(row, col int, value float64) {
+	if row > col {
+		row, col = col, row
+	}
+
+	if row < m.firstRow[col] {
+		if nums.IsCloseToZero(value) {
+			return
+		}
+
+		m.growColumnTo(col, row)
+	}
+
+	m.values[m.colStart[col]+row-m.firstRow[col]] = value
+}
+
+/*
+AddToValue adds the given value to the existing value in the indicated row and column, and its
+symmetric position.
+
+As both positions share the same storage, when assembling a symmetric matrix only the values of
+one of the triangles should be added.
+*/
+func (m *SkylineMat) AddToValue(row, col int, value float64) {
+	if row > col {
+		row, col = col, row
+	}
+
+	if row < m.firstRow[col] {
+		if nums.IsCloseToZero(value) {
+			return
+		}
+
+		m.growColumnTo(col, row)
+	}
+
+	m.values[m.colStart[col]+row-m.firstRow[col]] += value
+}
+
+/*
+SetZeroCol sets all the values in the given column as zero. To keep the matrix symmetric, the
+values in the row with the same index are also set to zero.
+*/
+func (m *SkylineMat) SetZeroCol(col int) {
+	for i := m.colStart[col]; i < m.colStart[col+1]; i++ {
+		m.values[i] = 0.0
+	}
+
+	for j := col + 1; j < m.size; j++ {
+		if m.firstRow[j] <= col {
+			m.values[m.colStart[j]+col-m.firstRow[j]] = 0.0
+		}
+	}
+}
+
+/*
+SetIdentityRow sets the given row as identity: one in the main diagonal value, and zeroes in all
+other positions of the row. To keep the matrix symmetric, the values in the column with the same
+index are also set to zero.
+*/
+func (m *SkylineMat) SetIdentityRow(row int) {
+	m.SetZeroCol(row)
+	m.values[m.colStart[row+1]-1] = 1.0
+}
+
+// growColumnTo extends the envelope of the given column upwards so that it starts at the row.
+func (m *SkylineMat) growColumnTo(col, row int) {
+	var (
+		extra  = m.firstRow[col] - row
+		values = make([]float64, len(m.values)+extra)
+		start  = m.colStart[col]
+	)
+
+	copy(values, m.values[:start])
+	copy(values[start+extra:], m.values[start:])
+
+	for j := col + 1; j <= m.size; j++ {
+		m.colStart[j] += extra
+	}
+
+	m.firstRow[col] = row
+	m.values = values
+}
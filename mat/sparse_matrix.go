@@ -97,33 +97,17 @@ func (m SparseMat) TimesVector(vector vec.ReadOnlyVector) vec.ReadOnlyVector {
 	return result
 }
 
-// TimesMatrix multiplies this matrix times other.
+/*
+TimesMatrix multiplies this matrix times other, returning a sparse matrix. Only the non-zero
+values of both matrices are visited, so the cost is proportional to the number of multiplications
+between them.
+*/
 func (m SparseMat) TimesMatrix(other ReadOnlyMatrix) ReadOnlyMatrix {
 	if m.cols != other.Rows() {
 		panic("Can't multiply matrices due to size mismatch")
 	}
 
-	var (
-		rows   = m.rows
-		cols   = other.Cols()
-		sum    float64
-		result = MakeSparse(rows, cols)
-	)
-
-	for i, row := range m.data {
-		for j := 0; j < cols; j++ {
-
-			sum = 0.0
-
-			for k, val := range row {
-				sum += val * other.Value(k, j)
-			}
-
-			result.SetValue(i, j, sum)
-		}
-	}
-
-	return result
+	return sparseTimesMatrix(m, other)
 }
 
 // RowTimesVector returns the result of multiplying the row at the given index times the given vector.
@@ -0,0 +1,141 @@
+package mat
+
+import "github.com/angelsolaorbaiceta/inkmath/nums"
+
+/*
+sparseTimesMatrix multiplies two matrices visiting only the non-zero values of both, and returns
+the result as a sparse matrix.
+
+It uses Gustavson's algorithm: each row of the result is accumulated in a dense array by adding
+the rows of the other matrix scaled by the non-zero values in the same row of m. The cost is thus
+proportional to the number of multiplications, plus the number of rows and columns. Values which
+cancel out to (nearly) zero aren't stored.
+*/
+func sparseTimesMatrix(m, other ReadOnlyMatrix) *SparseMat {
+	var (
+		result = MakeSparse(m.Rows(), other.Cols())
+		acc    = make([]float64, other.Cols())
+		// marker has, for each column, the row plus one in which it was last used in acc.
+		marker  = make([]int, other.Cols())
+		pattern = make([]int, 0)
+	)
+
+	for i := 0; i < m.Rows(); i++ {
+		pattern = pattern[:0]
+
+		ForEachNonZeroInRow(m, i, func(k int, a float64) {
+			ForEachNonZeroInRow(other, k, func(j int, b float64) {
+				if marker[j] != i+1 {
+					marker[j] = i + 1
+					acc[j] = 0.0
+					pattern = append(pattern, j)
+				}
+
+				acc[j] += a * b
+			})
+		})
+
+		var row map[int]float64
+		for _, j := range pattern {
+			if nums.IsCloseToZero(acc[j]) {
+				continue
+			}
+
+			if row == nil {
+				row = make(map[int]float64, len(pattern))
+				result.data[i] = row
+			}
+			row[j] = acc[j]
+		}
+	}
+
+	return result
+}
+
+/*
+ForEachNonZeroInRow calls fn with the column and value of each non-zero value in the given row of
+the matrix. Sparse and CSR matrices are iterated directly, without looking up their values.
+
+Dense matrices visit every stored value other than an exact zero, unlike their NonZeroIndicesAtRow,
+which also skips the values close to zero. This keeps the values of matrices in small units, like
+1e-11, in norms, factorizations and products. Views visit the values of the viewed matrix the same
+way.
+*/
+func ForEachNonZeroInRow(m ReadOnlyMatrix, row int, fn func(col int, value float64)) {
+	switch matrix := m.(type) {
+	case *SparseMat:
+		for col, value := range matrix.data[row] {
+			fn(col, value)
+		}
+	case SparseMat:
+		for col, value := range matrix.data[row] {
+			fn(col, value)
+		}
+	case *CSRMat:
+		for i := matrix.rowPtr[row]; i < matrix.rowPtr[row+1]; i++ {
+			fn(matrix.colIdx[i], matrix.values[i])
+		}
+	case CSRMat:
+		for i := matrix.rowPtr[row]; i < matrix.rowPtr[row+1]; i++ {
+			fn(matrix.colIdx[i], matrix.values[i])
+		}
+	case *DenseMat:
+		forEachNonZeroValue(matrix.data[row], fn)
+	case DenseMat:
+		forEachNonZeroValue(matrix.data[row], fn)
+	case *SymDenseMat:
+		forEachNonZeroInSymDenseRow(matrix, row, fn)
+	case SymDenseMat:
+		forEachNonZeroInSymDenseRow(&matrix, row, fn)
+	case *View:
+		ForEachNonZeroInRow(matrix.matrix, matrix.rows[row], func(col int, value float64) {
+			if viewCol := matrix.colPositions[col]; viewCol >= 0 {
+				fn(viewCol, value)
+			}
+		})
+	default:
+		for _, col := range m.NonZeroIndicesAtRow(row) {
+			fn(col, m.Value(row, col))
+		}
+	}
+}
+
+func forEachNonZeroValue(values []float64, fn func(col int, value float64)) {
+	for col, value := range values {
+		if value != 0.0 {
+			fn(col, value)
+		}
+	}
+}
+
+func forEachNonZeroInSymDenseRow(m *SymDenseMat, row int, fn func(col int, value float64)) {
+	for col := 0; col < m.size; col++ {
+		if value := m.data[m.index(row, col)]; value != 0.0 {
+			fn(col, value)
+		}
+	}
+}
+
+/*
+PtAP computes the Galerkin triple product Pᵀ A P, where A is a square matrix and P has as many
+rows as A. It's used to transform matrices to other coordinates (for example, the stiffness
+matrix of an element from local to global axes), to apply constraints, or to build the coarse
+operators in multigrid methods.
+
+The product is computed as two sparse products, Pᵀ (A P), visiting only the non-zero values. For a
+symmetric matrix A, the result is also symmetric.
+*/
+func PtAP(p, a ReadOnlyMatrix) *SparseMat {
+	if !IsSquare(a) || p.Rows() != a.Rows() {
+		panic("Can't compute the triple product due to size mismatch")
+	}
+
+	pt := MakeSparse(p.Cols(), p.Rows())
+	for row := 0; row < p.Rows(); row++ {
+		ForEachNonZeroInRow(p, row, func(col int, value float64) {
+			pt.SetValue(col, row, value)
+		})
+	}
+
+	return sparseTimesMatrix(pt, sparseTimesMatrix(a, p))
+}
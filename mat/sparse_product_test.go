@@ -0,0 +1,89 @@
+package mat
+
+import "testing"
+
+func TestSparseTimesMatrix(t *testing.T) {
+	var (
+		a = MakeSparseWithData(2, 3, []float64{
+			1, 0, 2,
+			0, 3, 0,
+		})
+		b = MakeSparseWithData(3, 2, []float64{
+			2, 1,
+			0, 4,
+			-1, 0,
+		})
+		want = []float64{
+			0, 1,
+			0, 12,
+		}
+	)
+
+	t.Run("sparse times sparse", func(t *testing.T) {
+		product := a.TimesMatrix(b)
+
+		assertMatrixContainsData(t, product, want)
+		// The first value cancels out, so it shouldn't be stored
+		if got := product.NonZeroIndicesAtRow(0); len(got) != 1 || got[0] != 1 {
+			t.Errorf("Want a single non-zero at column 1, got %v", got)
+		}
+	})
+
+	t.Run("sparse times dense", func(t *testing.T) {
+		product := a.TimesMatrix(CopyToDense(b))
+		assertMatrixContainsData(t, product, want)
+	})
+
+	t.Run("CSR times sparse", func(t *testing.T) {
+		product := MakeCSR(a).TimesMatrix(b)
+		assertMatrixContainsData(t, product, want)
+	})
+
+	t.Run("size mismatch", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected a panic")
+			}
+		}()
+
+		a.TimesMatrix(a)
+	})
+}
+
+func TestPtAP(t *testing.T) {
+	var (
+		a = MakeSparseWithData(3, 3, []float64{
+			4, -1, 0,
+			-1, 4, -1,
+			0, -1, 4,
+		})
+		p = MakeSparseWithData(3, 2, []float64{
+			1, 0,
+			0.5, 0.5,
+			0, 1,
+		})
+		want = Transpose(p).TimesMatrix(a).TimesMatrix(p)
+	)
+
+	got := PtAP(p, a)
+
+	if got.Rows() != 2 || got.Cols() != 2 {
+		t.Fatalf("Want a 2x2 matrix, got %dx%d", got.Rows(), got.Cols())
+	}
+	if !AreEqual(got, want) {
+		t.Errorf("Want %v, got %v", want, got)
+	}
+	if !IsSymmetric(got) {
+		t.Error("Expected a symmetric result")
+	}
+
+	t.Run("size mismatch", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected a panic")
+			}
+		}()
+
+		PtAP(MakeSparse(2, 2), a)
+	})
+}
@@ -0,0 +1,289 @@
+package mat
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+)
+
+const (
+	svgMarginLeft   = 50.0
+	svgMarginTop    = 30.0
+	svgMarginRight  = 10.0
+	svgMarginBottom = 10.0
+	svgFontSize     = 10.0
+	svgTickLength   = 4.0
+)
+
+// SVGOptions configures how a matrix sparsity pattern is rendered into an SVG spy plot.
+type SVGOptions struct {
+	// Size is the length, in pixels, of the largest side of the plotted matrix.
+	Size float64
+	// ZeroTolerance is the absolute value below or equal to which entries are considered zero.
+	ZeroTolerance float64
+	// Ticks is the approximate number of ticks, with the row and column indices, in each axis.
+	Ticks int
+	/*
+		BlockSize, if greater than one, draws separator lines every BlockSize rows and columns. This
+		is useful to distinguish the groups of degrees of freedom, like those of each node.
+	*/
+	BlockSize int
+	// HighlightDiagonal draws the main diagonal of the matrix.
+	HighlightDiagonal bool
+	/*
+		ShowBandwidth draws the bandwidth envelope: the lines parallel to the main diagonal which
+		enclose all the non-zero entries of the matrix.
+	*/
+	ShowBandwidth bool
+}
+
+// DefaultSVGOptions returns the options to render a 600 pixels spy plot with five ticks per axis.
+func DefaultSVGOptions() SVGOptions {
+	return SVGOptions{
+		Size:          600,
+		ZeroTolerance: 1e-10,
+		Ticks:         5,
+	}
+}
+
+/*
+WriteSVG renders the sparsity pattern of the matrix as an SVG spy plot, which is written to w.
+
+Every non-zero entry is drawn as a square, where the rows are plotted top to bottom and the
+columns left to right, as in the PNG images. Consecutive non-zero entries in a row are merged into
+a single rectangle, and only the non-zero indices of each row are visited, so the size of the
+output and the rendering time grow with the number of non-zero entries.
+*/
+func WriteSVG(w io.Writer, m ReadOnlyMatrix, options SVGOptions) error {
+	var (
+		rows, cols = m.Rows(), m.Cols()
+		buf        = bufio.NewWriter(w)
+	)
+
+	if rows == 0 || cols == 0 {
+		return ErrEmptyImage
+	}
+	if options.Size <= 0 {
+		return errors.New("mat: the SVG size must be positive")
+	}
+
+	var (
+		largest = rows
+		lower   = 0
+		upper   = 0
+	)
+	if cols > largest {
+		largest = cols
+	}
+
+	var (
+		cell   = options.Size / float64(largest)
+		width  = cell * float64(cols)
+		height = cell * float64(rows)
+	)
+
+	fmt.Fprintf(
+		buf,
+		"<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%s\" height=\"%s\" font-family=\"sans-serif\" font-size=\"%s\">\n",
+		svgNum(svgMarginLeft+width+svgMarginRight),
+		svgNum(svgMarginTop+height+svgMarginBottom),
+		svgNum(svgFontSize),
+	)
+	fmt.Fprintf(
+		buf,
+		"<g transform=\"translate(%s,%s)\">\n",
+		svgNum(svgMarginLeft),
+		svgNum(svgMarginTop),
+	)
+	fmt.Fprintf(
+		buf,
+		"<rect width=\"%s\" height=\"%s\" fill=\"white\" stroke=\"black\"/>\n",
+		svgNum(width),
+		svgNum(height),
+	)
+
+	// Non-zero entries
+	fmt.Fprintln(buf, "<g fill=\"#1f4e79\">")
+	for row := 0; row < rows; row++ {
+		indices := make([]int, 0)
+		for _, col := range m.NonZeroIndicesAtRow(row) {
+			if math.Abs(m.Value(row, col)) > options.ZeroTolerance {
+				indices = append(indices, col)
+			}
+		}
+		sort.Ints(indices)
+
+		if len(indices) > 0 {
+			if diff := row - indices[0]; diff > lower {
+				lower = diff
+			}
+			if diff := indices[len(indices)-1] - row; diff > upper {
+				upper = diff
+			}
+		}
+
+		for start := 0; start < len(indices); {
+			end := start + 1
+			for end < len(indices) && indices[end] == indices[end-1]+1 {
+				end++
+			}
+
+			fmt.Fprintf(
+				buf,
+				"<rect x=\"%s\" y=\"%s\" width=\"%s\" height=\"%s\"/>\n",
+				svgNum(cell*float64(indices[start])),
+				svgNum(cell*float64(row)),
+				svgNum(cell*float64(end-start)),
+				svgNum(cell),
+			)
+			start = end
+		}
+	}
+	fmt.Fprintln(buf, "</g>")
+
+	// Block separators
+	if options.BlockSize > 1 {
+		fmt.Fprintln(buf, "<g stroke=\"#999999\" stroke-width=\"0.5\">")
+		for col := options.BlockSize; col < cols; col += options.BlockSize {
+			writeSVGLine(buf, cell*float64(col), 0, cell*float64(col), height)
+		}
+		for row := options.BlockSize; row < rows; row += options.BlockSize {
+			writeSVGLine(buf, 0, cell*float64(row), width, cell*float64(row))
+		}
+		fmt.Fprintln(buf, "</g>")
+	}
+
+	// Main diagonal and bandwidth envelope
+	if options.HighlightDiagonal {
+		fmt.Fprintln(buf, "<g stroke=\"#d62728\" stroke-width=\"1\">")
+		writeSVGDiagonal(buf, 0, rows, cols, cell)
+		fmt.Fprintln(buf, "</g>")
+	}
+	if options.ShowBandwidth {
+		fmt.Fprintln(buf, "<g stroke=\"#ff7f0e\" stroke-width=\"1\" stroke-dasharray=\"4,2\">")
+		writeSVGDiagonal(buf, -(lower + 1), rows, cols, cell)
+		writeSVGDiagonal(buf, upper+1, rows, cols, cell)
+		fmt.Fprintln(buf, "</g>")
+	}
+
+	// Axis ticks
+	fmt.Fprintln(buf, "<g stroke=\"black\">")
+	colTicks, rowTicks := svgTicks(cols, options.Ticks), svgTicks(rows, options.Ticks)
+	for _, col := range colTicks {
+		x := cell * (float64(col) + 0.5)
+		writeSVGLine(buf, x, -svgTickLength, x, 0)
+	}
+	for _, row := range rowTicks {
+		y := cell * (float64(row) + 0.5)
+		writeSVGLine(buf, -svgTickLength, y, 0, y)
+	}
+	fmt.Fprintln(buf, "</g>")
+
+	fmt.Fprintln(buf, "<g text-anchor=\"middle\">")
+	for _, col := range colTicks {
+		fmt.Fprintf(
+			buf,
+			"<text x=\"%s\" y=\"%s\">%d</text>\n",
+			svgNum(cell*(float64(col)+0.5)),
+			svgNum(-svgTickLength-2),
+			col,
+		)
+	}
+	fmt.Fprintln(buf, "</g>")
+
+	fmt.Fprintln(buf, "<g text-anchor=\"end\" dominant-baseline=\"middle\">")
+	for _, row := range rowTicks {
+		fmt.Fprintf(
+			buf,
+			"<text x=\"%s\" y=\"%s\">%d</text>\n",
+			svgNum(-svgTickLength-2),
+			svgNum(cell*(float64(row)+0.5)),
+			row,
+		)
+	}
+	fmt.Fprintln(buf, "</g>")
+
+	fmt.Fprintln(buf, "</g>")
+	fmt.Fprintln(buf, "</svg>")
+
+	return buf.Flush()
+}
+
+/*
+writeSVGDiagonal draws the line, parallel to the main diagonal, which starts at the top left
+corner of the entry at (0, offset) for positive offsets and (-offset, 0) for negative ones,
+clipped to the matrix bounds.
+*/
+func writeSVGDiagonal(w io.Writer, offset, rows, cols int, cell float64) {
+	startRow, startCol := 0, offset
+	if offset < 0 {
+		startRow, startCol = -offset, 0
+	}
+
+	length := rows - startRow
+	if cols-startCol < length {
+		length = cols - startCol
+	}
+	if length <= 0 {
+		return
+	}
+
+	writeSVGLine(
+		w,
+		cell*float64(startCol),
+		cell*float64(startRow),
+		cell*float64(startCol+length),
+		cell*float64(startRow+length),
+	)
+}
+
+func writeSVGLine(w io.Writer, x1, y1, x2, y2 float64) {
+	fmt.Fprintf(
+		w,
+		"<line x1=\"%s\" y1=\"%s\" x2=\"%s\" y2=\"%s\"/>\n",
+		svgNum(x1),
+		svgNum(y1),
+		svgNum(x2),
+		svgNum(y2),
+	)
+}
+
+/*
+svgTicks returns the indices where to place approximately count ticks for an axis with size
+entries. The ticks are placed at round intervals (1, 2 or 5 times a power of ten), starting at
+zero.
+*/
+func svgTicks(size, count int) []int {
+	if count <= 0 {
+		return nil
+	}
+
+	var (
+		rawStep = float64(size) / float64(count)
+		power   = math.Pow(10, math.Floor(math.Log10(math.Max(rawStep, 1))))
+		step    = int(power)
+	)
+
+	for _, factor := range []float64{1, 2, 5, 10} {
+		if factor*power >= rawStep {
+			step = int(factor * power)
+			break
+		}
+	}
+
+	ticks := make([]int, 0, size/step+1)
+	for tick := 0; tick < size; tick += step {
+		ticks = append(ticks, tick)
+	}
+
+	return ticks
+}
+
+// svgNum formats the number with up to three decimals.
+func svgNum(x float64) string {
+	return strconv.FormatFloat(math.Round(x*1000)/1000, 'f', -1, 64)
+}
@@ -0,0 +1,104 @@
+package mat
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWriteSVG(t *testing.T) {
+	// 4x4 tridiagonal matrix with cell size 10
+	m := MakeSparseWithData(4, 4, []float64{
+		2, -1, 0, 0,
+		-1, 2, -1, 0,
+		0, -1, 2, -1,
+		0, 0, -1, 2,
+	})
+
+	render := func(options SVGOptions) string {
+		var buf bytes.Buffer
+		if err := WriteSVG(&buf, m, options); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		return buf.String()
+	}
+
+	options := DefaultSVGOptions()
+	options.Size = 40
+
+	t.Run("merges consecutive entries in a row", func(t *testing.T) {
+		svg := render(options)
+
+		if !strings.HasPrefix(svg, "<svg") || !strings.HasSuffix(svg, "</svg>\n") {
+			t.Errorf("Expected an SVG document, got %s", svg)
+		}
+		// One rect per row plus the background
+		if got := strings.Count(svg, "<rect"); got != 5 {
+			t.Errorf("Want 5 rects, got %d", got)
+		}
+		if !strings.Contains(svg, `<rect x="0" y="10" width="30" height="10"/>`) {
+			t.Error("Expected the second row entries to be merged")
+		}
+		if strings.Contains(svg, "<line x1=\"0\" y1=\"0\"") {
+			t.Error("Didn't expect the main diagonal")
+		}
+	})
+
+	t.Run("block separators", func(t *testing.T) {
+		options := options
+		options.BlockSize = 2
+		svg := render(options)
+
+		for _, line := range []string{
+			`<line x1="20" y1="0" x2="20" y2="40"/>`,
+			`<line x1="0" y1="20" x2="40" y2="20"/>`,
+		} {
+			if !strings.Contains(svg, line) {
+				t.Errorf("Expected separator %s", line)
+			}
+		}
+	})
+
+	t.Run("diagonal and bandwidth envelope", func(t *testing.T) {
+		options := options
+		options.HighlightDiagonal = true
+		options.ShowBandwidth = true
+		svg := render(options)
+
+		for _, line := range []string{
+			`<line x1="0" y1="0" x2="40" y2="40"/>`,
+			`<line x1="0" y1="20" x2="20" y2="40"/>`,
+			`<line x1="20" y1="0" x2="40" y2="20"/>`,
+		} {
+			if !strings.Contains(svg, line) {
+				t.Errorf("Expected line %s", line)
+			}
+		}
+	})
+
+	t.Run("empty matrix", func(t *testing.T) {
+		if err := WriteSVG(&bytes.Buffer{}, MakeSparse(0, 3), options); err != ErrEmptyImage {
+			t.Errorf("Want ErrEmptyImage, got %v", err)
+		}
+	})
+
+	t.Run("returns the writer errors", func(t *testing.T) {
+		if err := WriteSVG(failingWriter{}, m, options); err == nil {
+			t.Error("Expected an error")
+		}
+	})
+}
+
+func TestSVGTicks(t *testing.T) {
+	if got, want := svgTicks(4, 5), []int{0, 1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Want %v, got %v", want, got)
+	}
+	if got, want := svgTicks(1000, 5), []int{0, 200, 400, 600, 800}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Want %v, got %v", want, got)
+	}
+	if got, want := svgTicks(120, 4), []int{0, 50, 100}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Want %v, got %v", want, got)
+	}
+}
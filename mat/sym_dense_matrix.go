@@ -0,0 +1,118 @@
+package mat
+
+import (
+	"github.com/angelsolaorbaiceta/inkmath/nums"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+/*
+A SymDenseMat is a symmetric dense matrix where only the values of the upper triangle (including
+the main diagonal) are stored.
+
+The upper triangle is packed row after row in a single slice, so the memory required is about
+half the one of a DenseMat of the same size.
+*/
+type SymDenseMat struct {
+	size int
+	data []float64
+}
+
+/*
+MakeSymDense creates a new symmetric dense matrix with the given number of rows and columns all
+filled with zeroes.
+*/
+func MakeSymDense(size int) *SymDenseMat {
+	return &SymDenseMat{size, make([]float64, size*(size+1)/2)}
+}
+
+func (m SymDenseMat) symmetricStorage() {}
+
+// index returns the position in the packed data of the given row and column.
+func (m SymDenseMat) index(row, col int) int {
+	if row > col {
+		row, col = col, row
+	}
+
+	return row*m.size - row*(row-1)/2 + col - row
+}
+
+// Rows returns the number of rows in the matrix.
+func (m SymDenseMat) Rows() int { return m.size }
+
+// Cols returns the number of columns in the matrix.
+func (m SymDenseMat) Cols() int { return m.size }
+
+// Value returns the value at a given row and column.
+func (m SymDenseMat) Value(row, col int) float64 {
+	return m.data[m.index(row, col)]
+}
+
+// NonZeroIndicesAtRow returns a slice with all non-zero elements indices for the given row.
+func (m SymDenseMat) NonZeroIndicesAtRow(row int) []int {
+	indices := make([]int, 0)
+	for col := 0; col < m.size; col++ {
+		if !nums.IsCloseToZero(m.data[m.index(row, col)]) {
+			indices = append(indices, col)
+		}
+	}
+
+	return indices
+}
+
+// TimesVector creates a new vector result of multiplying this matrix and a vector.
+func (m SymDenseMat) TimesVector(v vec.ReadOnlyVector) vec.ReadOnlyVector {
+	if m.size != v.Length() {
+		panic("Can't multiply matrix vs vector due to size mismatch")
+	}
+
+	result := vec.Make(m.size)
+	for row := 0; row < m.size; row++ {
+		result.SetValue(row, m.rowTimesVector(row, v))
+	}
+
+	return result
+}
+
+// TimesMatrix multiplies this matrix with other.
+func (m SymDenseMat) TimesMatrix(other ReadOnlyMatrix) ReadOnlyMatrix {
+	if m.size != other.Rows() {
+		panic("Can't multiply matrices due to size mismatch")
+	}
+
+	var (
+		cols   = other.Cols()
+		sum    float64
+		result = MakeDense(m.size, cols)
+	)
+
+	for i := 0; i < m.size; i++ {
+		for j := 0; j < cols; j++ {
+			sum = 0.0
+			for k := 0; k < m.size; k++ {
+				sum += m.data[m.index(i, k)] * other.Value(k, j)
+			}
+
+			result.data[i][j] = sum
+		}
+	}
+
+	return result
+}
+
+// RowTimesVector returns the result of multiplying the row at the given index times the given vector.
+func (m SymDenseMat) RowTimesVector(row int, v vec.ReadOnlyVector) float64 {
+	if m.size != v.Length() {
+		panic("Can't multiply matrix row with vector due to size mismatch")
+	}
+
+	return m.rowTimesVector(row, v)
+}
+
+func (m SymDenseMat) rowTimesVector(row int, v vec.ReadOnlyVector) float64 {
+	result := 0.0
+	for col := 0; col < m.size; col++ {
+		result += m.data[m.index(row, col)] * v.Value(col)
+	}
+
+	return result
+}
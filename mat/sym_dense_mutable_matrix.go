@@ -0,0 +1,37 @@
+package mat
+
+// SetValue sets a value for a given row and column, and its symmetric position.
+func (m *SymDenseMat) SetValue(row, col int, value float64) {
+	m.data[m.index(row, col)] = value
+}
+
+/*
+AddToValue adds the given value to the existing value in the indicated row and column, and its
+symmetric position.
+
+As both positions share the same storage, when assembling a symmetric matrix only the values of
+one of the triangles should be added.
+*/
+func (m *SymDenseMat) AddToValue(row, col int, value float64) {
+	m.data[m.index(row, col)] += value
+}
+
+/*
+SetZeroCol sets all the values in the given column as zero. To keep the matrix symmetric, the
+values in the row with the same index are also set to zero.
+*/
+func (m *SymDenseMat) SetZeroCol(col int) {
+	for row := 0; row < m.size; row++ {
+		m.data[m.index(row, col)] = 0.0
+	}
+}
+
+/*
+SetIdentityRow sets the given row as identity: one in the main diagonal value, and zeroes in all
+other positions of the row. To keep the matrix symmetric, the values in the column with the same
+index are also set to zero.
+*/
+func (m *SymDenseMat) SetIdentityRow(row int) {
+	m.SetZeroCol(row)
+	m.data[m.index(row, row)] = 1.0
+}
@@ -0,0 +1,133 @@
+package mat
+
+import (
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+func TestSymmetricMatrix(t *testing.T) {
+	data := []float64{
+		4, 1, 0,
+		1, 3, -2,
+		0, -2, 5,
+	}
+
+	t.Run("reads the lower triangle from the upper one", func(t *testing.T) {
+		test := func(matrix MutableMatrix) {
+			matrix.SetValue(0, 0, 4)
+			matrix.SetValue(1, 0, 1)
+			matrix.SetValue(1, 1, 3)
+			matrix.SetValue(1, 2, -2)
+			matrix.SetValue(2, 2, 5)
+
+			assertMatrixContainsData(t, matrix, data)
+		}
+
+		test(MakeSymSparse(3))
+		test(MakeSymDense(3))
+	})
+
+	t.Run("adds to both symmetric positions", func(t *testing.T) {
+		test := func(matrix MutableMatrix) {
+			FillMatrixWithData(matrix, data)
+			matrix.AddToValue(2, 1, 10)
+
+			if got := matrix.Value(1, 2); got != 8 {
+				t.Errorf("Want 8, got %f", got)
+			}
+		}
+
+		test(MakeSymSparse(3))
+		test(MakeSymDense(3))
+	})
+
+	t.Run("is recognized as symmetric", func(t *testing.T) {
+		if !IsSymmetric(MakeSymSparse(3)) {
+			t.Error("Expected symmetric sparse matrix to be symmetric")
+		}
+		if !IsSymmetric(MakeSymDense(3)) {
+			t.Error("Expected symmetric dense matrix to be symmetric")
+		}
+	})
+
+	t.Run("non-zero indices at row", func(t *testing.T) {
+		matrix := MakeSymSparse(3)
+		FillMatrixWithData(matrix, data)
+
+		indices := matrix.NonZeroIndicesAtRow(1)
+		if len(indices) != 3 {
+			t.Errorf("Want 3 indices, got %v", indices)
+		}
+
+		matrix.SetValue(1, 0, 0)
+		assertIndices(t, matrix.NonZeroIndicesAtRow(1), []int{1, 2})
+		assertIndices(t, matrix.NonZeroIndicesAtRow(0), []int{0})
+
+		matrix.SetIdentityRow(2)
+		assertIndices(t, matrix.NonZeroIndicesAtRow(1), []int{1})
+		assertIndices(t, matrix.NonZeroIndicesAtRow(2), []int{2})
+	})
+
+	t.Run("times vector", func(t *testing.T) {
+		var (
+			v    = vec.MakeWithValues([]float64{1, 2, 3})
+			want = []float64{6, 1, 11}
+		)
+
+		test := func(matrix MutableMatrix) {
+			FillMatrixWithData(matrix, data)
+
+			if got := matrix.TimesVector(v); !vec.VectorContainsData(got, want) {
+				t.Errorf("Want %v, got %v", want, got)
+			}
+			if got := matrix.RowTimesVector(2, v); got != 11 {
+				t.Errorf("Want 11, got %f", got)
+			}
+		}
+
+		test(MakeSymSparse(3))
+		test(MakeSymDense(3))
+	})
+
+	t.Run("set identity row keeps symmetry", func(t *testing.T) {
+		want := []float64{
+			4, 0, 0,
+			0, 1, 0,
+			0, 0, 5,
+		}
+
+		test := func(matrix MutableMatrix) {
+			FillMatrixWithData(matrix, data)
+			matrix.SetIdentityRow(1)
+
+			assertMatrixContainsData(t, matrix, want)
+		}
+
+		test(MakeSymSparse(3))
+		test(MakeSymDense(3))
+	})
+}
+
+func BenchmarkSymSparseRowTimesVector(b *testing.B) {
+	var (
+		size   = 10000
+		matrix = MakeSymSparse(size)
+		v      = vec.Make(size)
+	)
+
+	for i := 0; i < size; i++ {
+		matrix.SetValue(i, i, 2)
+		if i+1 < size {
+			matrix.SetValue(i, i+1, -1)
+		}
+		v.SetValue(i, 1)
+	}
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for row := 0; row < size; row++ {
+			matrix.RowTimesVector(row, v)
+		}
+	}
+}
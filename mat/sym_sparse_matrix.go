@@ -0,0 +1,117 @@
+package mat
+
+import (
+	"sort"
+
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+/*
+A SymSparseMat is a symmetric sparse matrix where only the non-zero values of the upper triangle
+(including the main diagonal) are stored.
+
+Reading a value from the lower triangle returns the mirrored value of the upper one, and setting
+a value in any of the triangles sets it in both.
+*/
+type SymSparseMat struct {
+	size int
+	data map[int]map[int]float64
+	/*
+		lower has, for each row, the columns of its non-zero values below the main diagonal, which
+		are stored in the upper triangle as data[col][row]. It keeps the access to a whole row
+		proportional to its number of non-zero values.
+	*/
+	lower map[int]map[int]bool
+}
+
+// MakeSymSparse creates a new symmetric sparse matrix with the given number of rows and columns.
+func MakeSymSparse(size int) *SymSparseMat {
+	return &SymSparseMat{size, make(map[int]map[int]float64), make(map[int]map[int]bool)}
+}
+
+func (m SymSparseMat) symmetricStorage() {}
+
+// Rows returns the number of rows in the matrix.
+func (m SymSparseMat) Rows() int { return m.size }
+
+// Cols returns the number of columns in the matrix.
+func (m SymSparseMat) Cols() int { return m.size }
+
+// Value returns the value at a given row and column.
+func (m SymSparseMat) Value(row, col int) float64 {
+	if row > col {
+		row, col = col, row
+	}
+
+	if dataRow, hasRow := m.data[row]; hasRow {
+		if value, hasValue := dataRow[col]; hasValue {
+			return value
+		}
+	}
+
+	return 0.0
+}
+
+// NonZeroIndicesAtRow returns a slice with all non-zero elements indices for the given row, sorted.
+func (m SymSparseMat) NonZeroIndicesAtRow(row int) []int {
+	indices := make([]int, 0, len(m.lower[row])+len(m.data[row]))
+
+	for i := range m.lower[row] {
+		indices = append(indices, i)
+	}
+
+	for j := range m.data[row] {
+		indices = append(indices, j)
+	}
+
+	sort.Ints(indices)
+	return indices
+}
+
+// TimesVector multiplies this matrix and a vector.
+func (m SymSparseMat) TimesVector(v vec.ReadOnlyVector) vec.ReadOnlyVector {
+	if m.size != v.Length() {
+		panic("Can't multiply matrix and vector due to size mismatch")
+	}
+
+	result := vec.Make(m.size)
+
+	for i, dataRow := range m.data {
+		for j, val := range dataRow {
+			result.SetValue(i, result.Value(i)+val*v.Value(j))
+			if i != j {
+				result.SetValue(j, result.Value(j)+val*v.Value(i))
+			}
+		}
+	}
+
+	return result
+}
+
+// TimesMatrix multiplies this matrix times other.
+func (m SymSparseMat) TimesMatrix(other ReadOnlyMatrix) ReadOnlyMatrix {
+	if m.size != other.Rows() {
+		panic("Can't multiply matrices due to size mismatch")
+	}
+
+	return sparseTimesMatrix(m, other)
+}
+
+// RowTimesVector returns the result of multiplying the row at the given index times the given vector.
+func (m SymSparseMat) RowTimesVector(row int, v vec.ReadOnlyVector) float64 {
+	if m.size != v.Length() {
+		panic("Can't multiply matrix row with vector due to size mismatch")
+	}
+
+	result := 0.0
+
+	for i := range m.lower[row] {
+		result += m.data[i][row] * v.Value(i)
+	}
+
+	for j, val := range m.data[row] {
+		result += val * v.Value(j)
+	}
+
+	return result
+}
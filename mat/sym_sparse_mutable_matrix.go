@@ -0,0 +1,73 @@
+package mat
+
+import "github.com/angelsolaorbaiceta/inkmath/nums"
+
+// SetValue sets a value for a given row and column, and its symmetric position.
+func (m *SymSparseMat) SetValue(row, col int, value float64) {
+	if row > col {
+		row, col = col, row
+	}
+
+	if nums.IsCloseToZero(value) {
+		if dataRow, hasRow := m.data[row]; hasRow {
+			delete(dataRow, col)
+		}
+		if row != col {
+			delete(m.lower[col], row)
+		}
+	} else {
+		m.setValueToAdd(row, col, value)
+	}
+}
+
+// setValueToAdd stores a value in the upper triangle, given row <= col, even if it's close to zero.
+func (m *SymSparseMat) setValueToAdd(row, col int, value float64) {
+	if _, hasRow := m.data[row]; !hasRow {
+		m.data[row] = make(map[int]float64)
+	}
+	m.data[row][col] = value
+
+	if row != col {
+		if _, hasRow := m.lower[col]; !hasRow {
+			m.lower[col] = make(map[int]bool)
+		}
+		m.lower[col][row] = true
+	}
+}
+
+/*
+AddToValue adds the given value to the existing value in the indicated row and column, and its
+symmetric position.
+
+As both positions share the same storage, when assembling a symmetric matrix only the values of
+one of the triangles should be added.
+*/
+func (m *SymSparseMat) AddToValue(row, col int, value float64) {
+	m.SetValue(row, col, m.Value(row, col)+value)
+}
+
+/*
+SetZeroCol sets all the values in the given column as zero. To keep the matrix symmetric, the
+values in the row with the same index are also set to zero.
+*/
+func (m *SymSparseMat) SetZeroCol(col int) {
+	for i := range m.lower[col] {
+		delete(m.data[i], col)
+	}
+	for j := range m.data[col] {
+		delete(m.lower[j], col)
+	}
+
+	delete(m.lower, col)
+	delete(m.data, col)
+}
+
+/*
+SetIdentityRow sets the given row as identity: one in the main diagonal value, and zeroes in all
+other positions of the row. To keep the matrix symmetric, the values in the column with the same
+index are also set to zero.
+*/
+func (m *SymSparseMat) SetIdentityRow(row int) {
+	m.SetZeroCol(row)
+	m.SetValue(row, row, 1.0)
+}
@@ -0,0 +1,118 @@
+package mat
+
+/*
+A SymbolicCholesky is the result of analyzing the sparsity pattern of a symmetric matrix to
+predict the structure of its Cholesky factor L, without computing it.
+*/
+type SymbolicCholesky struct {
+	// Parent is the elimination tree: Parent[j] is the parent of column j, or -1 for the roots.
+	Parent []int
+	// ColCounts has the number of non-zero values in each column of L, including the diagonal.
+	ColCounts []int
+}
+
+/*
+AnalyzeCholesky computes the elimination tree and the column counts of the Cholesky factor of a
+symmetric matrix from its sparsity pattern. The pattern is symmetrized, so a value in either
+triangle is considered in both, and matrices storing only one of their triangles can be analyzed.
+
+The numerical values aren't considered, so cancellations are ignored and the predicted non-zero
+values are an upper bound. To analyze the factor of a permuted matrix, use a View:
+
+	symbolic := mat.AnalyzeCholesky(mat.MakeView(m, permutation, permutation))
+*/
+func AnalyzeCholesky(m ReadOnlyMatrix) *SymbolicCholesky {
+	if !IsSquare(m) {
+		panic("Can't analyze the Cholesky factorization of a non-square matrix")
+	}
+
+	var (
+		size      = m.Rows()
+		lower     = lowerPattern(m)
+		parent    = make([]int, size)
+		ancestor  = make([]int, size)
+		colCounts = make([]int, size)
+		mark      = make([]int, size)
+	)
+
+	// Elimination tree, using Liu's algorithm with path compression
+	for k := 0; k < size; k++ {
+		parent[k] = -1
+		ancestor[k] = -1
+
+		for _, i := range lower[k] {
+			for i != -1 && i < k {
+				next := ancestor[i]
+				ancestor[i] = k
+				if next == -1 {
+					parent[i] = k
+				}
+
+				i = next
+			}
+		}
+	}
+
+	/*
+		Column counts: the non-zero values in row k of L are in the columns of the row subtree,
+		which has the paths in the elimination tree from each non-zero A(k, i) up to k.
+	*/
+	for k := 0; k < size; k++ {
+		mark[k] = k + 1
+		colCounts[k]++
+
+		for _, i := range lower[k] {
+			for ; mark[i] != k+1; i = parent[i] {
+				mark[i] = k + 1
+				colCounts[i]++
+			}
+		}
+	}
+
+	return &SymbolicCholesky{parent, colCounts}
+}
+
+// NonZeroCount returns the predicted number of non-zero values in the Cholesky factor L.
+func (s SymbolicCholesky) NonZeroCount() int {
+	count := 0
+	for _, colCount := range s.ColCounts {
+		count += colCount
+	}
+
+	return count
+}
+
+/*
+lowerPattern returns, for each row of a symmetric matrix, the columns below the diagonal with a
+non-zero value. The pattern is symmetrized, so the upper triangle values are also included.
+*/
+func lowerPattern(m ReadOnlyMatrix) [][]int {
+	var (
+		size    = m.Rows()
+		pattern = make([]map[int]bool, size)
+		lower   = make([][]int, size)
+	)
+
+	for row := range pattern {
+		pattern[row] = make(map[int]bool)
+	}
+
+	for row := 0; row < size; row++ {
+		for _, col := range m.NonZeroIndicesAtRow(row) {
+			if col < row {
+				pattern[row][col] = true
+			} else if col > row {
+				pattern[col][row] = true
+			}
+		}
+	}
+
+	for row, cols := range pattern {
+		lower[row] = make([]int, 0, len(cols))
+		for col := range cols {
+			lower[row] = append(lower[row], col)
+		}
+	}
+
+	return lower
+}
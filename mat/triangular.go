@@ -0,0 +1,254 @@
+package mat
+
+import (
+	"github.com/angelsolaorbaiceta/inkmath/nums"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+/*
+A LowerTriangular is a read-only lower triangular matrix over the values of another square
+matrix, its storage, which can be sparse or dense. Only the values in the lower triangle of the
+storage are part of the matrix: the ones above the main diagonal are considered zero.
+
+When the matrix has a unit diagonal, the values in the main diagonal are one, regardless of the
+values in the storage. This is the case of the L factor of the LU or LDLᵀ factorizations, which
+can then share the storage with other factors.
+
+Systems of equations with a triangular matrix are solved by substitution, visiting only the
+non-zero values of the storage.
+*/
+type LowerTriangular struct {
+	triangular
+}
+
+/*
+An UpperTriangular is a read-only upper triangular matrix over the values of another square
+matrix. Only the values in the upper triangle of the storage are part of the matrix, the ones
+below the main diagonal are considered zero. See LowerTriangular for the details.
+*/
+type UpperTriangular struct {
+	triangular
+}
+
+// MakeLowerTriangular creates a lower triangular matrix over the values of the given storage.
+func MakeLowerTriangular(storage ReadOnlyMatrix, unitDiagonal bool) *LowerTriangular {
+	return &LowerTriangular{makeTriangular(storage, true, unitDiagonal)}
+}
+
+// MakeUpperTriangular creates an upper triangular matrix over the values of the given storage.
+func MakeUpperTriangular(storage ReadOnlyMatrix, unitDiagonal bool) *UpperTriangular {
+	return &UpperTriangular{makeTriangular(storage, false, unitDiagonal)}
+}
+
+/*
+SolveInPlace solves the system L x = b by forward substitution, overwriting b with the solution.
+The matrix shouldn't have zeroes in its main diagonal.
+*/
+func (m LowerTriangular) SolveInPlace(b vec.MutableVector) {
+	m.assertSystemSize(b)
+	m.substituteRows(b, 0, m.size, 1)
+}
+
+// SolveTransposedInPlace solves the system Lᵀ x = b by back substitution, overwriting b with the
+// solution.
+func (m LowerTriangular) SolveTransposedInPlace(b vec.MutableVector) {
+	m.assertSystemSize(b)
+	m.substituteCols(b, m.size-1, -1, -1)
+}
+
+// Solve solves the system L x = b, returning the solution in a new vector.
+func (m LowerTriangular) Solve(b vec.ReadOnlyVector) vec.ReadOnlyVector {
+	x := b.Clone().AsMutable()
+	m.SolveInPlace(x)
+
+	return x
+}
+
+// SolveTransposed solves the system Lᵀ x = b, returning the solution in a new vector.
+func (m LowerTriangular) SolveTransposed(b vec.ReadOnlyVector) vec.ReadOnlyVector {
+	x := b.Clone().AsMutable()
+	m.SolveTransposedInPlace(x)
+
+	return x
+}
+
+/*
+SolveInPlace solves the system U x = b by back substitution, overwriting b with the solution.
+The matrix shouldn't have zeroes in its main diagonal.
+*/
+func (m UpperTriangular) SolveInPlace(b vec.MutableVector) {
+	m.assertSystemSize(b)
+	m.substituteRows(b, m.size-1, -1, -1)
+}
+
+// SolveTransposedInPlace solves the system Uᵀ x = b by forward substitution, overwriting b with
+// the solution.
+func (m UpperTriangular) SolveTransposedInPlace(b vec.MutableVector) {
+	m.assertSystemSize(b)
+	m.substituteCols(b, 0, m.size, 1)
+}
+
+// Solve solves the system U x = b, returning the solution in a new vector.
+func (m UpperTriangular) Solve(b vec.ReadOnlyVector) vec.ReadOnlyVector {
+	x := b.Clone().AsMutable()
+	m.SolveInPlace(x)
+
+	return x
+}
+
+// SolveTransposed solves the system Uᵀ x = b, returning the solution in a new vector.
+func (m UpperTriangular) SolveTransposed(b vec.ReadOnlyVector) vec.ReadOnlyVector {
+	x := b.Clone().AsMutable()
+	m.SolveTransposedInPlace(x)
+
+	return x
+}
+
+// triangular has the implementation shared by the lower and upper triangular matrices.
+type triangular struct {
+	storage             ReadOnlyMatrix
+	size                int
+	lower, unitDiagonal bool
+}
+
+func makeTriangular(storage ReadOnlyMatrix, lower, unitDiagonal bool) triangular {
+	if !IsSquare(storage) {
+		panic("Can't create a triangular matrix over a non-square matrix")
+	}
+
+	return triangular{storage, storage.Rows(), lower, unitDiagonal}
+}
+
+// HasUnitDiagonal returns whether the values in the main diagonal are implicitly one.
+func (m triangular) HasUnitDiagonal() bool { return m.unitDiagonal }
+
+// Size returns the number of rows (and columns) in the matrix.
+func (m triangular) Size() int { return m.size }
+
+// Rows returns the number of rows in the matrix.
+func (m triangular) Rows() int { return m.size }
+
+// Cols returns the number of columns in the matrix.
+func (m triangular) Cols() int { return m.size }
+
+// Value returns the value at a given row and column, which is zero outside the triangle.
+func (m triangular) Value(row, col int) float64 {
+	switch {
+	case row == col:
+		return m.diagonal(row)
+	case (col < row) == m.lower:
+		return m.storage.Value(row, col)
+	default:
+		return 0.0
+	}
+}
+
+// NonZeroIndicesAtRow returns a slice with all non-zero elements indices for the given row.
+func (m triangular) NonZeroIndicesAtRow(row int) []int {
+	indices := make([]int, 0)
+	m.forEachOffDiagonalInRow(row, func(col int, _ float64) {
+		indices = append(indices, col)
+	})
+
+	if !nums.IsCloseToZero(m.diagonal(row)) {
+		indices = append(indices, row)
+	}
+
+	return indices
+}
+
+// RowTimesVector returns the result of multiplying the row at the given index times the vector.
+func (m triangular) RowTimesVector(row int, v vec.ReadOnlyVector) float64 {
+	if m.size != v.Length() {
+		panic("Can't multiply matrix row vs vector due to size mismatch")
+	}
+
+	result := m.diagonal(row) * v.Value(row)
+	m.forEachOffDiagonalInRow(row, func(col int, value float64) {
+		result += value * v.Value(col)
+	})
+
+	return result
+}
+
+// TimesVector creates a new vector result of multiplying this matrix and a vector.
+func (m triangular) TimesVector(v vec.ReadOnlyVector) vec.ReadOnlyVector {
+	if m.size != v.Length() {
+		panic("Can't multiply matrix vs vector due to size mismatch")
+	}
+
+	result := vec.Make(m.size)
+	for row := 0; row < m.size; row++ {
+		result.SetValue(row, m.RowTimesVector(row, v))
+	}
+
+	return result
+}
+
+// TimesMatrix multiplies this matrix with other.
+func (m triangular) TimesMatrix(other ReadOnlyMatrix) ReadOnlyMatrix {
+	if m.size != other.Rows() {
+		panic("Can't multiply matrices due to size mismatch")
+	}
+
+	return sparseTimesMatrix(m, other)
+}
+
+func (m triangular) diagonal(i int) float64 {
+	if m.unitDiagonal {
+		return 1.0
+	}
+
+	return m.storage.Value(i, i)
+}
+
+// forEachOffDiagonalInRow calls fn with the non-zero values of the row inside the triangle,
+// excluding the main diagonal.
+func (m triangular) forEachOffDiagonalInRow(row int, fn func(col int, value float64)) {
+	ForEachNonZeroInRow(m.storage, row, func(col int, value float64) {
+		if col != row && (col < row) == m.lower {
+			fn(col, value)
+		}
+	})
+}
+
+/*
+substituteRows solves the system visiting the rows from first to end (exclusive) with the given
+step, computing each unknown from the already computed ones in its row:
+
+	x_i = (b_i - Σ a_ij x_j) / a_ii
+*/
+func (m triangular) substituteRows(x vec.MutableVector, first, end, step int) {
+	for i := first; i != end; i += step {
+		value := x.Value(i)
+		m.forEachOffDiagonalInRow(i, func(col int, a float64) {
+			value -= a * x.Value(col)
+		})
+
+		x.SetValue(i, value/m.diagonal(i))
+	}
+}
+
+/*
+substituteCols solves the transposed system visiting the rows from first to end (exclusive) with
+the given step. Each row is a column of the transposed matrix, so once its unknown is computed, its
+contribution is subtracted from the remaining free terms:
+
+	x_i = b_i / a_ii, then b_j -= a_ij x_i
+*/
+func (m triangular) substituteCols(x vec.MutableVector, first, end, step int) {
+	for i := first; i != end; i += step {
+		value := x.Value(i) / m.diagonal(i)
+		x.SetValue(i, value)
+
+		m.forEachOffDiagonalInRow(i, func(col int, a float64) {
+			x.SetValue(col, x.Value(col)-a*value)
+		})
+	}
+}
+
+func (m triangular) assertSystemSize(b vec.ReadOnlyVector) {
+	if m.size != b.Length() {
+		panic("Can't solve system due to size mismatch")
+	}
+}
@@ -0,0 +1,107 @@
+package mat
+
+import (
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+var (
+	_ Factorization = LowerTriangular{}
+	_ Factorization = UpperTriangular{}
+)
+
+func TestLowerTriangular(t *testing.T) {
+	var (
+		storage = MakeDenseWithData(3, 3, []float64{
+			2, 9, 9,
+			1, 3, 9,
+			4, -1, 5,
+		})
+		lower = MakeLowerTriangular(storage, false)
+		x     = vec.MakeWithValues([]float64{1, 2, 3})
+	)
+
+	t.Run("values", func(t *testing.T) {
+		assertMatrixContainsData(t, lower, []float64{
+			2, 0, 0,
+			1, 3, 0,
+			4, -1, 5,
+		})
+		assertIndices(t, lower.NonZeroIndicesAtRow(1), []int{0, 1})
+	})
+
+	t.Run("solve", func(t *testing.T) {
+		b := vec.MakeWithValues([]float64{2, 7, 17})
+		if !lower.TimesVector(x).Equals(b) {
+			t.Fatalf("Want L x = %v, got %v", b, lower.TimesVector(x))
+		}
+
+		if got := lower.Solve(b); !got.Equals(x) {
+			t.Errorf("Want %v, got %v", x, got)
+		}
+
+		lower.SolveInPlace(b)
+		if !b.Equals(x) {
+			t.Errorf("Want %v, got %v", x, b)
+		}
+	})
+
+	t.Run("solve transposed", func(t *testing.T) {
+		b := vec.MakeWithValues([]float64{16, 3, 15})
+		if got := lower.SolveTransposed(b); !got.Equals(x) {
+			t.Errorf("Want %v, got %v", x, got)
+		}
+	})
+
+	t.Run("unit diagonal", func(t *testing.T) {
+		unitLower := MakeLowerTriangular(storage, true)
+
+		if !unitLower.HasUnitDiagonal() || unitLower.Value(2, 2) != 1.0 {
+			t.Error("Expected the diagonal values to be one")
+		}
+		if got := unitLower.Solve(vec.MakeWithValues([]float64{1, 3, 5})); !got.Equals(x) {
+			t.Errorf("Want %v, got %v", x, got)
+		}
+	})
+}
+
+func TestUpperTriangular(t *testing.T) {
+	var (
+		storage = MakeSparse(3, 3)
+		x       = vec.MakeWithValues([]float64{1, 2, 3})
+	)
+
+	FillMatrixWithData(storage, []float64{
+		2, 9, 9,
+		1, 3, 9,
+		4, -1, 5,
+	})
+	upper := MakeUpperTriangular(storage, false)
+
+	assertMatrixContainsData(t, upper, []float64{
+		2, 9, 9,
+		0, 3, 9,
+		0, 0, 5,
+	})
+
+	if got := upper.Solve(vec.MakeWithValues([]float64{47, 33, 15})); !got.Equals(x) {
+		t.Errorf("Want %v, got %v", x, got)
+	}
+
+	b := vec.MakeWithValues([]float64{2, 15, 42})
+	upper.SolveTransposedInPlace(b)
+	if !b.Equals(x) {
+		t.Errorf("Want %v, got %v", x, b)
+	}
+}
+
+func TestTriangularSizeMismatch(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic")
+		}
+	}()
+
+	MakeLowerTriangular(MakeSquareDense(3), false).SolveInPlace(vec.Make(2))
+}
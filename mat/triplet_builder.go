@@ -0,0 +1,171 @@
+package mat
+
+import (
+	"sort"
+
+	"github.com/angelsolaorbaiceta/inkmath/nums"
+)
+
+/*
+A TripletBuilder assembles a sparse matrix from (row, column, value) entries, also known as
+coordinate (COO) format.
+
+The entries can be added in any order, and the same position can be added more than once: the
+values for repeated positions are summed when the builder is compressed into a matrix. This is
+the usual way of assembling stiffness matrices, where many elements contribute to the same
+position, but avoids paying the cost of a map access for each of them.
+*/
+type TripletBuilder struct {
+	rows, cols int
+	entries    []triplet
+	compressed bool
+}
+
+type triplet struct {
+	row, col int
+	value    float64
+}
+
+/*
+MakeTripletBuilder creates a new builder for a matrix with the given number of rows and columns.
+
+The estimatedNonZeros is used to reserve the capacity for the entries in advance. An estimation
+of zero is valid, in which case the capacity grows as entries are added.
+*/
+func MakeTripletBuilder(rows, cols, estimatedNonZeros int) *TripletBuilder {
+	return &TripletBuilder{
+		rows:       rows,
+		cols:       cols,
+		entries:    make([]triplet, 0, estimatedNonZeros),
+		compressed: true,
+	}
+}
+
+// Rows returns the number of rows in the matrix being built.
+func (b *TripletBuilder) Rows() int { return b.rows }
+
+// Cols returns the number of columns in the matrix being built.
+func (b *TripletBuilder) Cols() int { return b.cols }
+
+/*
+Reserve makes sure the builder has capacity for, at least, the given number of entries without
+needing to grow its storage.
+*/
+func (b *TripletBuilder) Reserve(entries int) {
+	if entries <= cap(b.entries) {
+		return
+	}
+
+	reserved := make([]triplet, len(b.entries), entries)
+	copy(reserved, b.entries)
+	b.entries = reserved
+}
+
+/*
+Add adds the value at the given row and column. If there is already a value at that position,
+both are summed.
+*/
+func (b *TripletBuilder) Add(row, col int, value float64) {
+	if row < 0 || row >= b.rows || col < 0 || col >= b.cols {
+		panic("Can't add value outside the matrix bounds")
+	}
+
+	b.entries = append(b.entries, triplet{row, col, value})
+	b.compressed = false
+}
+
+// Len returns the number of entries stored in the builder, which may include repeated positions.
+func (b *TripletBuilder) Len() int {
+	return len(b.entries)
+}
+
+/*
+NonZeroCount returns the number of non-zero values the built matrix will have, once the values
+in repeated positions are summed. The builder isn't modified, so the entries are summed in a copy.
+*/
+func (b *TripletBuilder) NonZeroCount() int {
+	if b.compressed {
+		return len(b.entries)
+	}
+
+	entries := make([]triplet, len(b.entries))
+	copy(entries, b.entries)
+
+	return len(compressTriplets(entries))
+}
+
+// ToSparse creates a new sparse matrix with the values added to the builder.
+func (b *TripletBuilder) ToSparse() *SparseMat {
+	b.compress()
+
+	matrix := MakeSparse(b.rows, b.cols)
+	for _, entry := range b.entries {
+		matrix.SetValue(entry.row, entry.col, entry.value)
+	}
+
+	return matrix
+}
+
+// ToCSR creates a new CSR matrix with the values added to the builder.
+func (b *TripletBuilder) ToCSR() *CSRMat {
+	b.compress()
+
+	var (
+		nnz    = len(b.entries)
+		rowPtr = make([]int, b.rows+1)
+		colIdx = make([]int, nnz)
+		values = make([]float64, nnz)
+	)
+
+	for i, entry := range b.entries {
+		rowPtr[entry.row+1]++
+		colIdx[i] = entry.col
+		values[i] = entry.value
+	}
+
+	for row := 0; row < b.rows; row++ {
+		rowPtr[row+1] += rowPtr[row]
+	}
+
+	return &CSRMat{b.rows, b.cols, rowPtr, colIdx, values}
+}
+
+// compress leaves a single entry per non-zero position in the builder. See compressTriplets.
+func (b *TripletBuilder) compress() {
+	if b.compressed {
+		return
+	}
+
+	b.entries = compressTriplets(b.entries)
+	b.compressed = true
+}
+
+/*
+compressTriplets sorts the entries by row and column, sums the values in repeated positions and
+removes the resulting zeroes, leaving a single entry per non-zero position. The entries are
+compressed in place, and the slice with the remaining ones is returned.
+*/
+func compressTriplets(entries []triplet) []triplet {
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].row != entries[j].row {
+			return entries[i].row < entries[j].row
+		}
+		return entries[i].col < entries[j].col
+	})
+
+	n := 0
+	for i := 0; i < len(entries); {
+		current := entries[i]
+
+		for i++; i < len(entries) && entries[i].row == current.row && entries[i].col == current.col; i++ {
+			current.value += entries[i].value
+		}
+
+		if !nums.IsCloseToZero(current.value) {
+			entries[n] = current
+			n++
+		}
+	}
+
+	return entries[:n]
+}
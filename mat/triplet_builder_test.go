@@ -0,0 +1,70 @@
+package mat
+
+import "testing"
+
+func TestTripletBuilder(t *testing.T) {
+	var (
+		wantData = []float64{
+			3, 0, 0,
+			0, 0, 5,
+		}
+		makeBuilder = func() *TripletBuilder {
+			builder := MakeTripletBuilder(2, 3, 4)
+			builder.Add(1, 2, 4)
+			builder.Add(0, 0, 1)
+			builder.Add(0, 1, 7)
+			builder.Add(1, 2, 1)
+			builder.Add(0, 0, 2)
+			builder.Add(0, 1, -7)
+
+			return builder
+		}
+	)
+
+	t.Run("sums repeated entries", func(t *testing.T) {
+		builder := makeBuilder()
+
+		if got := builder.Len(); got != 6 {
+			t.Errorf("Want 6 entries, got %d", got)
+		}
+		if got := builder.NonZeroCount(); got != 2 {
+			t.Errorf("Want 2 non-zero values, got %d", got)
+		}
+		if got := builder.Len(); got != 6 {
+			t.Errorf("Want 6 entries after counting the non-zero values, got %d", got)
+		}
+	})
+
+	t.Run("builds a sparse matrix", func(t *testing.T) {
+		assertMatrixContainsData(t, makeBuilder().ToSparse(), wantData)
+	})
+
+	t.Run("builds a CSR matrix", func(t *testing.T) {
+		csr := makeBuilder().ToCSR()
+
+		assertMatrixContainsData(t, csr, wantData)
+		if got := csr.NonZeroCount(); got != 2 {
+			t.Errorf("Want 2 non-zero values, got %d", got)
+		}
+	})
+
+	t.Run("can keep adding entries after building", func(t *testing.T) {
+		builder := makeBuilder()
+		builder.ToCSR()
+		builder.Add(1, 0, 9)
+
+		assertMatrixContainsData(t, builder.ToCSR(), []float64{3, 0, 0, 9, 0, 5})
+	})
+
+	t.Run("reserves capacity", func(t *testing.T) {
+		builder := makeBuilder()
+		builder.Reserve(100)
+
+		if got := cap(builder.entries); got != 100 {
+			t.Errorf("Want capacity of 100, got %d", got)
+		}
+		if got := builder.Len(); got != 6 {
+			t.Errorf("Want 6 entries, got %d", got)
+		}
+	})
+}
@@ -0,0 +1,230 @@
+package mat
+
+import "github.com/angelsolaorbaiceta/inkmath/vec"
+
+/*
+A View is a read-only matrix made of a subset of the rows and columns of another matrix, without
+copying its values. The i-th row of the view is the row rows[i] of the matrix, and the j-th column
+of the view, the column cols[j].
+
+Views are useful to work with the blocks of a partitioned matrix, like the free and fixed degrees
+of freedom blocks of a stiffness matrix. Changes in the viewed matrix are seen through the view.
+Use CopyToSparse or CopyToDense to get an independent copy of its values.
+*/
+type View struct {
+	matrix     ReadOnlyMatrix
+	rows, cols []int
+	// colPositions has, for each column of the viewed matrix, its column in the view or -1.
+	colPositions []int
+}
+
+/*
+MakeView creates a view of the given rows and columns of the matrix, which are included in the
+view in the given order.
+
+Views of views are created directly over the original matrix, so they don't add indirections.
+It panics if any of the indices is out of the matrix bounds or appears more than once.
+*/
+func MakeView(m ReadOnlyMatrix, rows, cols []int) *View {
+	if view, ok := m.(*View); ok {
+		return MakeView(view.matrix, composeIndices(view.rows, rows), composeIndices(view.cols, cols))
+	}
+
+	var (
+		rowsCopy     = make([]int, len(rows))
+		colsCopy     = make([]int, len(cols))
+		seenRows     = make(map[int]bool, len(rows))
+		colPositions = make([]int, m.Cols())
+	)
+
+	for i := range colPositions {
+		colPositions[i] = -1
+	}
+
+	for i, row := range rows {
+		if row < 0 || row >= m.Rows() {
+			panic("Can't create a view with rows out of the matrix bounds")
+		}
+		if seenRows[row] {
+			panic("Can't create a view with repeated rows")
+		}
+
+		seenRows[row] = true
+		rowsCopy[i] = row
+	}
+
+	for j, col := range cols {
+		if col < 0 || col >= m.Cols() {
+			panic("Can't create a view with columns out of the matrix bounds")
+		}
+		if colPositions[col] >= 0 {
+			panic("Can't create a view with repeated columns")
+		}
+
+		colPositions[col] = j
+		colsCopy[j] = col
+	}
+
+	return &View{m, rowsCopy, colsCopy, colPositions}
+}
+
+// composeIndices returns the outer indices of the inner ones: outer[inner[i]].
+func composeIndices(outer, inner []int) []int {
+	composed := make([]int, len(inner))
+	for i, index := range inner {
+		if index < 0 || index >= len(outer) {
+			panic("Can't create a view with indices out of the matrix bounds")
+		}
+
+		composed[i] = outer[index]
+	}
+
+	return composed
+}
+
+/*
+IndexRange returns the indices from start (inclusive) to end (exclusive), to create views of
+contiguous rows or columns.
+*/
+func IndexRange(start, end int) []int {
+	if end < start {
+		panic("Can't create an index range whose end is before its start")
+	}
+
+	indices := make([]int, end-start)
+	for i := range indices {
+		indices[i] = start + i
+	}
+
+	return indices
+}
+
+// Rows returns the number of rows in the view.
+func (v View) Rows() int { return len(v.rows) }
+
+// Cols returns the number of columns in the view.
+func (v View) Cols() int { return len(v.cols) }
+
+// Value returns the value at the given row and column of the view.
+func (v View) Value(row, col int) float64 {
+	return v.matrix.Value(v.rows[row], v.cols[col])
+}
+
+/*
+NonZeroIndicesAtRow returns the columns of the view with a non-zero value in the given row. Only
+the non-zero values of the row in the viewed matrix are visited.
+*/
+func (v View) NonZeroIndicesAtRow(row int) []int {
+	var (
+		matrixCols = v.matrix.NonZeroIndicesAtRow(v.rows[row])
+		indices    = make([]int, 0, len(matrixCols))
+	)
+
+	for _, col := range matrixCols {
+		if position := v.colPositions[col]; position >= 0 {
+			indices = append(indices, position)
+		}
+	}
+
+	return indices
+}
+
+// RowTimesVector returns the result of multiplying the given row of the view by a vector.
+func (v View) RowTimesVector(row int, vector vec.ReadOnlyVector) float64 {
+	if len(v.cols) != vector.Length() {
+		panic("Can't multiply matrix row with vector due to size mismatch")
+	}
+
+	return v.rowTimesVector(row, vector)
+}
+
+func (v View) rowTimesVector(row int, vector vec.ReadOnlyVector) float64 {
+	var (
+		matrixRow = v.rows[row]
+		result    = 0.0
+	)
+
+	for _, col := range v.matrix.NonZeroIndicesAtRow(matrixRow) {
+		if position := v.colPositions[col]; position >= 0 {
+			result += v.matrix.Value(matrixRow, col) * vector.Value(position)
+		}
+	}
+
+	return result
+}
+
+// TimesVector multiplies the view by a vector.
+func (v View) TimesVector(vector vec.ReadOnlyVector) vec.ReadOnlyVector {
+	if len(v.cols) != vector.Length() {
+		panic("Can't multiply matrix and vector due to size mismatch")
+	}
+
+	result := vec.Make(len(v.rows))
+	for row := range v.rows {
+		result.SetValue(row, v.rowTimesVector(row, vector))
+	}
+
+	return result
+}
+
+// TimesMatrix multiplies the view by another matrix, returning a sparse matrix.
+func (v View) TimesMatrix(other ReadOnlyMatrix) ReadOnlyMatrix {
+	if len(v.cols) != other.Rows() {
+		panic("Can't multiply matrices due to size mismatch")
+	}
+
+	return sparseTimesMatrix(v, other)
+}
+
+/*
+CopyToSparse copies the non-zero values of any matrix, like a view, into a new sparse matrix.
+Every stored value other than an exact zero is copied, even if it's close to zero.
+*/
+func CopyToSparse(m ReadOnlyMatrix) *SparseMat {
+	result := MakeSparse(m.Rows(), m.Cols())
+
+	for row := 0; row < m.Rows(); row++ {
+		ForEachNonZeroInRow(m, row, func(col int, value float64) {
+			result.setValueToAdd(row, col, value)
+		})
+	}
+
+	return result
+}
+
+/*
+CopyToSymSparse copies the non-zero values of the upper triangle of any square matrix, like a view,
+into a new symmetric sparse matrix. The values of the lower triangle are ignored, so the matrix
+should be symmetric. Every stored value other than an exact zero is copied, even if it's close to
+zero.
+*/
+func CopyToSymSparse(m ReadOnlyMatrix) *SymSparseMat {
+	if !IsSquare(m) {
+		panic("Can't copy a non-square matrix into a symmetric matrix")
+	}
+
+	result := MakeSymSparse(m.Rows())
+
+	for row := 0; row < m.Rows(); row++ {
+		ForEachNonZeroInRow(m, row, func(col int, value float64) {
+			if col >= row {
+				result.setValueToAdd(row, col, value)
+			}
+		})
+	}
+
+	return result
+}
+
+// CopyToDense copies the values of any matrix, like a view, into a new dense matrix.
+func CopyToDense(m ReadOnlyMatrix) *DenseMat {
+	result := MakeDense(m.Rows(), m.Cols())
+
+	for row := 0; row < m.Rows(); row++ {
+		ForEachNonZeroInRow(m, row, func(col int, value float64) {
+			result.SetValue(row, col, value)
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,140 @@
+package mat
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+func TestView(t *testing.T) {
+	m := MakeSparseWithData(4, 4, []float64{
+		1, 2, 0, 3,
+		0, 4, 5, 0,
+		6, 0, 7, 0,
+		0, 8, 0, 9,
+	})
+
+	t.Run("values of the rows and columns", func(t *testing.T) {
+		view := MakeView(m, []int{2, 0}, []int{3, 0, 2})
+
+		if view.Rows() != 2 || view.Cols() != 3 {
+			t.Fatalf("Want a 2x3 view, got %dx%d", view.Rows(), view.Cols())
+		}
+		assertMatrixContainsData(t, view, []float64{
+			0, 6, 7,
+			3, 1, 0,
+		})
+	})
+
+	t.Run("non zero indices", func(t *testing.T) {
+		view := MakeView(m, []int{2, 0}, []int{3, 0, 2})
+
+		for row, want := range [][]int{{1, 2}, {0, 1}} {
+			got := view.NonZeroIndicesAtRow(row)
+			sort.Ints(got)
+			assertIndices(t, got, want)
+		}
+	})
+
+	t.Run("sees changes in the matrix", func(t *testing.T) {
+		var (
+			m    = MakeIdentity(3)
+			view = MakeView(m, IndexRange(1, 3), IndexRange(0, 2))
+		)
+
+		m.SetValue(2, 0, 5.0)
+		if got := view.Value(1, 0); got != 5.0 {
+			t.Errorf("Want 5, got %f", got)
+		}
+	})
+
+	t.Run("views compose", func(t *testing.T) {
+		var (
+			outer = MakeView(m, []int{3, 1, 0}, []int{1, 2, 3})
+			inner = MakeView(outer, []int{2, 0}, []int{0, 2})
+		)
+
+		if inner.matrix != ReadOnlyMatrix(m) {
+			t.Error("Expected the composed view to be over the original matrix")
+		}
+		assertMatrixContainsData(t, inner, []float64{
+			2, 3,
+			8, 9,
+		})
+	})
+
+	t.Run("products", func(t *testing.T) {
+		var (
+			view   = MakeView(m, []int{0, 1}, []int{1, 2})
+			v      = vec.MakeWithValues([]float64{1, 2})
+			result = view.TimesVector(v)
+		)
+
+		if want := []float64{2, 14}; !vec.VectorContainsData(result, want) {
+			t.Errorf("Want %v, got %v", want, result)
+		}
+		if got := view.RowTimesVector(1, v); got != 14 {
+			t.Errorf("Want 14, got %f", got)
+		}
+
+		product := view.TimesMatrix(MakeIdentity(2))
+		assertMatrixContainsData(t, product, []float64{2, 0, 4, 5})
+	})
+
+	t.Run("copies", func(t *testing.T) {
+		var (
+			view   = MakeView(m, []int{1, 2}, []int{0, 1})
+			want   = []float64{0, 4, 6, 0}
+			sparse = CopyToSparse(view)
+			dense  = CopyToDense(view)
+		)
+
+		assertMatrixContainsData(t, sparse, want)
+		assertMatrixContainsData(t, dense, want)
+
+		m := MakeIdentity(2)
+		copied := CopyToSparse(MakeView(m, IndexRange(0, 2), IndexRange(0, 2)))
+		m.SetValue(0, 0, 3.0)
+		if copied.Value(0, 0) != 1.0 {
+			t.Error("Expected the copy to be independent of the matrix")
+		}
+	})
+
+	t.Run("copies keep small dense values", func(t *testing.T) {
+		var (
+			small = MakeDenseWithData(2, 2, []float64{1e-11, 0, 0, 1})
+			view  = MakeView(small, IndexRange(0, 2), IndexRange(0, 2))
+		)
+
+		if got := CopyToSparse(view).Value(0, 0); got != 1e-11 {
+			t.Errorf("Want 1e-11 in the sparse copy, got %g", got)
+		}
+		if got := CopyToDense(view).Value(0, 0); got != 1e-11 {
+			t.Errorf("Want 1e-11 in the dense copy, got %g", got)
+		}
+		if got := CopyToSymSparse(view).Value(0, 0); got != 1e-11 {
+			t.Errorf("Want 1e-11 in the symmetric sparse copy, got %g", got)
+		}
+	})
+
+	t.Run("repeated indices", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected a panic")
+			}
+		}()
+
+		MakeView(m, []int{0, 0}, []int{1})
+	})
+
+	t.Run("indices out of bounds", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected a panic")
+			}
+		}()
+
+		MakeView(m, []int{0}, []int{4})
+	})
+}
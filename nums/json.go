@@ -0,0 +1,64 @@
+package nums
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+/*
+A JSONFloat is a float64 which can always be encoded into JSON.
+
+JSON numbers can't represent NaN or infinite values, so these are encoded as the "NaN", "+Inf"
+and "-Inf" strings. Finite values are encoded as numbers, using the shortest representation that
+decodes to the exact same value.
+*/
+type JSONFloat float64
+
+// MarshalJSON encodes the number into JSON.
+func (f JSONFloat) MarshalJSON() ([]byte, error) {
+	val := float64(f)
+
+	switch {
+	case math.IsNaN(val):
+		return []byte(`"NaN"`), nil
+	case math.IsInf(val, 1):
+		return []byte(`"+Inf"`), nil
+	case math.IsInf(val, -1):
+		return []byte(`"-Inf"`), nil
+	default:
+		return strconv.AppendFloat(nil, val, 'g', -1, 64), nil
+	}
+}
+
+// UnmarshalJSON decodes a number, or one of the "NaN", "+Inf" and "-Inf" strings, from JSON.
+func (f *JSONFloat) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+
+		switch text {
+		case "NaN":
+			*f = JSONFloat(math.NaN())
+		case "+Inf", "Inf":
+			*f = JSONFloat(math.Inf(1))
+		case "-Inf":
+			*f = JSONFloat(math.Inf(-1))
+		default:
+			return fmt.Errorf("nums: invalid JSON number %q", text)
+		}
+
+		return nil
+	}
+
+	var val float64
+	if err := json.Unmarshal(data, &val); err != nil {
+		return err
+	}
+
+	*f = JSONFloat(val)
+	return nil
+}
@@ -0,0 +1,37 @@
+package nums
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestJSONFloat(t *testing.T) {
+	var (
+		values = []JSONFloat{1.5, 0.1, -3e-300, JSONFloat(math.NaN()), JSONFloat(math.Inf(1)), JSONFloat(math.Inf(-1))}
+		want   = `[1.5,0.1,-3e-300,"NaN","+Inf","-Inf"]`
+	)
+
+	encoded, err := json.Marshal(values)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(encoded) != want {
+		t.Errorf("Want %s, got %s", want, encoded)
+	}
+
+	var decoded []JSONFloat
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for i, val := range decoded {
+		if val != values[i] && !(math.IsNaN(float64(val)) && math.IsNaN(float64(values[i]))) {
+			t.Errorf("Want %v, got %v", values[i], val)
+		}
+	}
+
+	if err := json.Unmarshal([]byte(`"one"`), &decoded[0]); err == nil {
+		t.Error("Expected an error decoding an invalid string")
+	}
+}
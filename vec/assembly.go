@@ -0,0 +1,29 @@
+package vec
+
+/*
+AddSubVector adds the values of a local vector, like the loads of an element, into the global
+vector. The dofs slice maps each local index to the global one, so the value at i of the local
+vector is added to the global vector at dofs[i]. Degrees of freedom set to -1 are skipped.
+*/
+func AddSubVector(global MutableVector, dofs []int, local ReadOnlyVector) {
+	AddScaledSubVector(global, dofs, 1.0, local)
+}
+
+// AddScaledSubVector adds the values of the local vector, scaled by a factor, into the global one.
+// See AddSubVector for the details.
+func AddScaledSubVector(global MutableVector, dofs []int, factor float64, local ReadOnlyVector) {
+	if local.Length() != len(dofs) {
+		panic("Can't add sub-vector due to size mismatch")
+	}
+
+	for i, dof := range dofs {
+		if dof < -1 || dof >= global.Length() {
+			panic("Can't add sub-vector with degrees of freedom out of the vector bounds")
+		}
+		if dof < 0 {
+			continue
+		}
+
+		global.SetValue(dof, global.Value(dof)+factor*local.Value(i))
+	}
+}
@@ -0,0 +1,30 @@
+package vec
+
+import "testing"
+
+func TestAddSubVector(t *testing.T) {
+	var (
+		global = MakeWithValues([]float64{1, 1, 1, 1})
+		local  = MakeWithValues([]float64{10, 20, 30})
+	)
+
+	AddSubVector(global, []int{3, -1, 0}, local)
+	if want := []float64{31, 1, 1, 11}; !VectorContainsData(global, want) {
+		t.Errorf("Want %v, got %v", want, global)
+	}
+
+	AddScaledSubVector(global, []int{1, 2, -1}, -0.5, local)
+	if want := []float64{31, -4, -9, 11}; !VectorContainsData(global, want) {
+		t.Errorf("Want %v, got %v", want, global)
+	}
+
+	t.Run("size mismatch", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected a panic")
+			}
+		}()
+
+		AddSubVector(global, []int{0, 1}, local)
+	})
+}
@@ -0,0 +1,93 @@
+package vec
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"math"
+)
+
+/*
+The binary format of a vector is made of the following little-endian encoded fields:
+
+	- Magic (4 bytes): the "INKV" string
+	- Version (1 byte): the version of the format, currently 1
+	- Length (8 bytes): the number of values in the vector
+	- Values (8 bytes each): the IEEE 754 bits of each of the values
+	- Checksum (4 bytes): the CRC-32 (IEEE) checksum of all the preceding bytes
+*/
+const (
+	binaryMagic   = "INKV"
+	binaryVersion = 1
+)
+
+var (
+	// ErrBinaryFormat is returned when the binary data doesn't contain a vector.
+	ErrBinaryFormat = errors.New("vec: invalid binary data")
+	// ErrBinaryVersion is returned when the binary data uses an unknown version of the format.
+	ErrBinaryVersion = errors.New("vec: unsupported binary format version")
+	// ErrBinaryChecksum is returned when the binary data is corrupted.
+	ErrBinaryChecksum = errors.New("vec: binary data checksum mismatch")
+)
+
+// MarshalBinary encodes the vector into a versioned binary format with a checksum.
+func (v Vector) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, len(binaryMagic)+1+8+8*v.length+4)
+	data = append(data, binaryMagic...)
+	data = append(data, binaryVersion)
+	data = appendUint64(data, uint64(v.length))
+
+	for _, val := range v.data {
+		data = appendUint64(data, math.Float64bits(val))
+	}
+
+	return appendUint32(data, crc32.ChecksumIEEE(data)), nil
+}
+
+// UnmarshalBinary decodes a vector encoded with MarshalBinary, overwriting this vector.
+func (v *Vector) UnmarshalBinary(data []byte) error {
+	headerSize := len(binaryMagic) + 1 + 8
+
+	if len(data) < headerSize+4 || string(data[:len(binaryMagic)]) != binaryMagic {
+		return ErrBinaryFormat
+	}
+
+	var (
+		payload  = data[:len(data)-4]
+		checksum = binary.LittleEndian.Uint32(data[len(data)-4:])
+	)
+
+	if crc32.ChecksumIEEE(payload) != checksum {
+		return ErrBinaryChecksum
+	}
+	if payload[len(binaryMagic)] != binaryVersion {
+		return ErrBinaryVersion
+	}
+
+	length := binary.LittleEndian.Uint64(payload[len(binaryMagic)+1:])
+	if length > uint64(len(payload))/8 || uint64(len(payload)-headerSize) != 8*length {
+		return ErrBinaryFormat
+	}
+
+	v.length = int(length)
+	v.data = make([]float64, v.length)
+	for i := range v.data {
+		v.data[i] = math.Float64frombits(binary.LittleEndian.Uint64(payload[headerSize+8*i:]))
+	}
+
+	return nil
+}
+
+func appendUint64(data []byte, value uint64) []byte {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], value)
+
+	return append(data, buf[:]...)
+}
+
+func appendUint32(data []byte, value uint32) []byte {
+	var buf [4]byte
+	binary.LittleEndian.PutUint32(buf[:], value)
+
+	return append(data, buf[:]...)
+}
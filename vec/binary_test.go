@@ -0,0 +1,54 @@
+package vec
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"testing"
+)
+
+func TestBinaryEncoding(t *testing.T) {
+	want := MakeWithValues([]float64{1.5, 0, -3e-8})
+
+	t.Run("round trip", func(t *testing.T) {
+		encoded, err := want.(*Vector).MarshalBinary()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		var got Vector
+		if err := got.UnmarshalBinary(encoded); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !got.Equals(want) {
+			t.Errorf("Want %v, got %v", want, got)
+		}
+	})
+
+	t.Run("works with gob", func(t *testing.T) {
+		var (
+			buf bytes.Buffer
+			got *Vector
+		)
+
+		if err := gob.NewEncoder(&buf).Encode(want); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if err := gob.NewDecoder(&buf).Decode(&got); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !got.Equals(want) {
+			t.Errorf("Want %v, got %v", want, got)
+		}
+	})
+
+	t.Run("detects corrupted data", func(t *testing.T) {
+		encoded, _ := want.(*Vector).MarshalBinary()
+		encoded[len(encoded)-6] ^= 0x01
+
+		var got Vector
+		if err := got.UnmarshalBinary(encoded); !errors.Is(err, ErrBinaryChecksum) {
+			t.Errorf("Want checksum error, got %v", err)
+		}
+	})
+}
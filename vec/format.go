@@ -0,0 +1,94 @@
+package vec
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// formatMaxLength is the largest number of values formatted without eliding the middle ones.
+	formatMaxLength = 10
+	// formatEdgeLength is the number of values formatted at each side of the elided middle.
+	formatEdgeLength = 4
+)
+
+/*
+Format implements the fmt.Formatter interface, so the vector can be printed using the %v, %f, %F,
+%e, %E, %g and %G verbs, which are applied to each value. The width and precision are honored, as
+well as the '+', ' ' and '-' flags:
+
+	[1.000  2.000  3.000]
+
+Vectors with more than ten values have their middle values elided, unless the '#' flag is used.
+*/
+func (v Vector) Format(f fmt.State, verb rune) {
+	valueFormat, ok := formatVerb(f, verb)
+	if !ok {
+		fmt.Fprintf(f, "%%!%c(%T=%d)", verb, v, v.length)
+		return
+	}
+
+	var (
+		width, _  = f.Width()
+		leftAlign = f.Flag('-')
+		elide     = !f.Flag('#') && v.length > formatMaxLength
+		builder   strings.Builder
+	)
+
+	builder.WriteByte('[')
+	for i := 0; i < v.length; i++ {
+		if i > 0 {
+			builder.WriteString("  ")
+		}
+
+		if elide && i == formatEdgeLength {
+			builder.WriteString("…")
+			i = v.length - formatEdgeLength - 1
+			continue
+		}
+
+		var (
+			value   = fmt.Sprintf(valueFormat, v.data[i])
+			padding = ""
+		)
+		if length := utf8.RuneCountInString(value); length < width {
+			padding = strings.Repeat(" ", width-length)
+		}
+
+		if leftAlign {
+			builder.WriteString(value + padding)
+		} else {
+			builder.WriteString(padding + value)
+		}
+	}
+	builder.WriteByte(']')
+
+	fmt.Fprint(f, builder.String())
+}
+
+/*
+formatVerb returns the format used to print each of the values of a vector given the formatting
+state and verb, or false if the verb isn't supported.
+*/
+func formatVerb(f fmt.State, verb rune) (string, bool) {
+	switch verb {
+	case 'v':
+		verb = 'g'
+	case 'f', 'F', 'e', 'E', 'g', 'G':
+	default:
+		return "", false
+	}
+
+	format := "%"
+	if f.Flag('+') {
+		format += "+"
+	} else if f.Flag(' ') {
+		format += " "
+	}
+	if precision, ok := f.Precision(); ok {
+		format += fmt.Sprintf(".%d", precision)
+	}
+
+	return format + string(verb), true
+}
@@ -0,0 +1,31 @@
+package vec
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	v := MakeWithValues([]float64{1, -2.5, 30})
+
+	tests := []struct {
+		format string
+		vector ReadOnlyVector
+		want   string
+	}{
+		{"%v", v, "[1  -2.5  30]"},
+		{"%6.2f", v, "[  1.00   -2.50   30.00]"},
+		{"%-4g", v, "[1     -2.5  30  ]"},
+		{"%+.0f", v, "[+1  -2  +30]"},
+		{"%v", Make(12), "[0  0  0  0  …  0  0  0  0]"},
+		{"%#v", Make(12), "[0  0  0  0  0  0  0  0  0  0  0  0]"},
+		{"%v", Make(0), "[]"},
+		{"%d", v, "%!d(vec.Vector=3)"},
+	}
+
+	for _, test := range tests {
+		if got := fmt.Sprintf(test.format, test.vector); got != test.want {
+			t.Errorf("Format %q: want %s, got %s", test.format, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package vec
+
+import (
+	"encoding/json"
+
+	"github.com/angelsolaorbaiceta/inkmath/nums"
+)
+
+/*
+MarshalJSON encodes the vector as a JSON array with its values. NaN and infinite values are
+encoded as the "NaN", "+Inf" and "-Inf" strings.
+*/
+func (v Vector) MarshalJSON() ([]byte, error) {
+	values := make([]nums.JSONFloat, v.length)
+	for i, val := range v.data {
+		values[i] = nums.JSONFloat(val)
+	}
+
+	return json.Marshal(values)
+}
+
+// UnmarshalJSON decodes a vector encoded with MarshalJSON, overwriting this vector.
+func (v *Vector) UnmarshalJSON(data []byte) error {
+	var values []nums.JSONFloat
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	v.length = len(values)
+	v.data = make([]float64, v.length)
+	for i, val := range values {
+		v.data[i] = float64(val)
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package vec
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestJSONEncoding(t *testing.T) {
+	var (
+		v    = MakeWithValues([]float64{1, -0.5, math.Inf(1)})
+		want = `[1,-0.5,"+Inf"]`
+	)
+
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(encoded) != want {
+		t.Errorf("Want %s, got %s", want, encoded)
+	}
+
+	var decoded Vector
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if decoded.Length() != 3 || decoded.Value(1) != -0.5 || !math.IsInf(decoded.Value(2), 1) {
+		t.Errorf("Want %v, got %v", v, decoded)
+	}
+}
@@ -0,0 +1,191 @@
+package vec
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+/*
+A MatrixMarketError is returned when a Matrix Market file can't be read as a vector because it's
+malformed or uses a feature which isn't supported.
+*/
+type MatrixMarketError struct {
+	// Line is the number of the line, starting at one, where the error was found.
+	Line int
+	Msg  string
+}
+
+func (err MatrixMarketError) Error() string {
+	return fmt.Sprintf("matrix market: line %d: %s", err.Line, err.Msg)
+}
+
+/*
+ReadMatrixMarket reads a vector in Matrix Market (.mtx) format.
+
+The vector is expected to be stored as a general matrix with a single column (or a single row),
+either in array or coordinate format, with real or integer values. This is the usual way the
+right-hand sides of linear systems are distributed.
+
+Any problem reading the file is returned as a MatrixMarketError with the offending line number.
+*/
+func ReadMatrixMarket(r io.Reader) (MutableVector, error) {
+	var (
+		scanner = bufio.NewScanner(r)
+		line    = 0
+		fields  []string
+	)
+
+	fail := func(format string, args ...interface{}) (MutableVector, error) {
+		return nil, MatrixMarketError{line, fmt.Sprintf(format, args...)}
+	}
+
+	// nextFields returns the fields of the next non-empty, non-comment line.
+	nextFields := func() bool {
+		for scanner.Scan() {
+			line++
+			text := strings.TrimSpace(scanner.Text())
+			if text == "" || strings.HasPrefix(text, "%") {
+				continue
+			}
+
+			fields = strings.Fields(text)
+			return true
+		}
+
+		return false
+	}
+
+	if !scanner.Scan() {
+		line++
+		return fail("missing header")
+	}
+	line++
+
+	header := strings.Fields(strings.ToLower(scanner.Text()))
+	if len(header) != 5 || header[0] != "%%matrixmarket" || header[1] != "matrix" {
+		return fail("invalid header %q", scanner.Text())
+	}
+
+	var (
+		format   = header[2]
+		field    = header[3]
+		symmetry = header[4]
+	)
+
+	if format != "array" && format != "coordinate" {
+		return fail("unsupported format %q", format)
+	}
+	if field != "real" && field != "integer" {
+		return fail("unsupported field %q", field)
+	}
+	if symmetry != "general" {
+		return fail("unsupported symmetry %q for a vector", symmetry)
+	}
+
+	if !nextFields() {
+		return fail("missing size line")
+	}
+
+	wantSizes := 2
+	if format == "coordinate" {
+		wantSizes = 3
+	}
+	if len(fields) != wantSizes {
+		return fail("want %d values in size line, got %d", wantSizes, len(fields))
+	}
+
+	sizes := make([]int, len(fields))
+	for i, field := range fields {
+		var err error
+		if sizes[i], err = strconv.Atoi(field); err != nil || sizes[i] < 0 {
+			return fail("invalid size %q", field)
+		}
+	}
+
+	if sizes[0] != 1 && sizes[1] != 1 {
+		return fail("a vector needs a single row or column, got %dx%d", sizes[0], sizes[1])
+	}
+
+	length := sizes[0] * sizes[1]
+
+	if format == "array" {
+		// The values are read before creating the vector, so that its size is backed by the file
+		values := make([]float64, 0)
+		for i := 0; i < length; i++ {
+			if !nextFields() {
+				return fail("unexpected end of file, missing values")
+			}
+			if len(fields) != 1 {
+				return fail("want 1 value per line, got %d", len(fields))
+			}
+
+			value, err := strconv.ParseFloat(fields[0], 64)
+			if err != nil {
+				return fail("invalid value %q", fields[0])
+			}
+
+			values = append(values, value)
+		}
+
+		return MakeWithValues(values), nil
+	}
+
+	if sizes[2] > length {
+		return fail("%d entries don't fit in a vector of length %d", sizes[2], length)
+	}
+
+	vector := Make(length)
+
+	for i := 0; i < sizes[2]; i++ {
+		if !nextFields() {
+			return fail("unexpected end of file, want %d entries, got %d", sizes[2], i)
+		}
+		if len(fields) != 3 {
+			return fail("want 3 values per entry, got %d", len(fields))
+		}
+
+		row, errRow := strconv.Atoi(fields[0])
+		col, errCol := strconv.Atoi(fields[1])
+		if errRow != nil || errCol != nil {
+			return fail("invalid entry indices %q, %q", fields[0], fields[1])
+		}
+		if row < 1 || row > sizes[0] || col < 1 || col > sizes[1] {
+			return fail("entry (%d, %d) out of the vector bounds", row, col)
+		}
+
+		value, err := strconv.ParseFloat(fields[2], 64)
+		if err != nil {
+			return fail("invalid value %q", fields[2])
+		}
+
+		index := row - 1
+		if sizes[0] == 1 {
+			index = col - 1
+		}
+
+		vector.SetValue(index, vector.Value(index)+value)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fail("%v", err)
+	}
+
+	return vector, nil
+}
+
+// WriteMatrixMarket writes the given vector in Matrix Market (.mtx) array format, as a column.
+func WriteMatrixMarket(w io.Writer, v ReadOnlyVector) error {
+	buf := bufio.NewWriter(w)
+
+	fmt.Fprintln(buf, "%%MatrixMarket matrix array real general")
+	fmt.Fprintf(buf, "%d 1\n", v.Length())
+
+	for i := 0; i < v.Length(); i++ {
+		fmt.Fprintln(buf, strconv.FormatFloat(v.Value(i), 'g', -1, 64))
+	}
+
+	return buf.Flush()
+}
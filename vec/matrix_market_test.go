@@ -0,0 +1,83 @@
+package vec
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReadMatrixMarket(t *testing.T) {
+	t.Run("array format", func(t *testing.T) {
+		file := "%%MatrixMarket matrix array real general\n3 1\n1\n-2.5\n3\n"
+
+		v, err := ReadMatrixMarket(strings.NewReader(file))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if want := []float64{1, -2.5, 3}; !VectorContainsData(v, want) {
+			t.Errorf("Want %v, got %v", want, v)
+		}
+	})
+
+	t.Run("coordinate format", func(t *testing.T) {
+		file := "%%MatrixMarket matrix coordinate real general\n3 1 1\n2 1 7\n"
+
+		v, err := ReadMatrixMarket(strings.NewReader(file))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if want := []float64{0, 7, 0}; !VectorContainsData(v, want) {
+			t.Errorf("Want %v, got %v", want, v)
+		}
+	})
+
+	t.Run("errors report the line number", func(t *testing.T) {
+		file := "%%MatrixMarket matrix array real general\n2 1\n1\nabc\n"
+
+		_, err := ReadMatrixMarket(strings.NewReader(file))
+
+		var mmErr MatrixMarketError
+		if !errors.As(err, &mmErr) {
+			t.Fatalf("Want a MatrixMarketError, got %v", err)
+		}
+		if mmErr.Line != 4 {
+			t.Errorf("Want error at line 4, got %d", mmErr.Line)
+		}
+	})
+
+	t.Run("sizes in the header aren't trusted", func(t *testing.T) {
+		files := []string{
+			"%%MatrixMarket matrix array real general\n1000000000000000 1\n1\n",
+			"%%MatrixMarket matrix coordinate real general\n3 1 1000000000000000\n1 1 1.0\n",
+		}
+
+		for _, file := range files {
+			_, err := ReadMatrixMarket(strings.NewReader(file))
+
+			var mmErr MatrixMarketError
+			if !errors.As(err, &mmErr) {
+				t.Errorf("Want a MatrixMarketError, got %v", err)
+			}
+		}
+	})
+}
+
+func TestWriteMatrixMarket(t *testing.T) {
+	var (
+		buf  bytes.Buffer
+		want = MakeWithValues([]float64{1.25, 0, -3})
+	)
+
+	if err := WriteMatrixMarket(&buf, want); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	got, err := ReadMatrixMarket(&buf)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !got.Equals(want) {
+		t.Errorf("Want %v, got %v", want, got)
+	}
+}
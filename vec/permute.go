@@ -0,0 +1,35 @@
+package vec
+
+/*
+Permute returns a new vector with the values of v reordered by the permutation, which has, for
+each new index, the old index: result[i] = v[permutation[i]].
+*/
+func Permute(v ReadOnlyVector, permutation []int) MutableVector {
+	if len(permutation) != v.Length() {
+		panic("Can't permute the vector due to size mismatch")
+	}
+
+	result := makeVector(v.Length())
+	for newIndex, oldIndex := range permutation {
+		result.data[newIndex] = v.Value(oldIndex)
+	}
+
+	return result
+}
+
+/*
+Unpermute undoes the permutation of a vector permuted with Permute, returning a new vector with
+result[permutation[i]] = v[i].
+*/
+func Unpermute(v ReadOnlyVector, permutation []int) MutableVector {
+	if len(permutation) != v.Length() {
+		panic("Can't unpermute the vector due to size mismatch")
+	}
+
+	result := makeVector(v.Length())
+	for newIndex, oldIndex := range permutation {
+		result.data[oldIndex] = v.Value(newIndex)
+	}
+
+	return result
+}
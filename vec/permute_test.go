@@ -0,0 +1,18 @@
+package vec
+
+import "testing"
+
+func TestPermute(t *testing.T) {
+	var (
+		v           = MakeWithValues([]float64{10, 20, 30})
+		permutation = []int{2, 0, 1}
+		permuted    = Permute(v, permutation)
+	)
+
+	if !VectorContainsData(permuted, []float64{30, 10, 20}) {
+		t.Errorf("Wrong permuted vector: %v", permuted)
+	}
+	if got := Unpermute(permuted, permutation); !got.Equals(v) {
+		t.Errorf("Want %v, got %v", v, got)
+	}
+}
@@ -0,0 +1,143 @@
+package vec
+
+import (
+	"math"
+
+	"github.com/angelsolaorbaiceta/inkmath/nums"
+)
+
+/*
+A View is a read-only vector made of a subset of the values of another vector, without copying
+them. The i-th value of the view is the value at indices[i] of the vector.
+
+Changes in the viewed vector are seen through the view. Its AsMutable and Clone methods return an
+independent copy of the values in the view.
+*/
+type View struct {
+	vector  ReadOnlyVector
+	indices []int
+}
+
+/*
+MakeView creates a view of the values of the vector at the given indices, in the given order.
+
+Views of views are created directly over the original vector, so they don't add indirections.
+It panics if any of the indices is out of the vector bounds.
+*/
+func MakeView(v ReadOnlyVector, indices []int) *View {
+	var (
+		vector      = v
+		viewIndices = make([]int, len(indices))
+	)
+
+	for i, index := range indices {
+		if index < 0 || index >= v.Length() {
+			panic("Can't create a view with indices out of the vector bounds")
+		}
+
+		viewIndices[i] = index
+	}
+
+	if view, ok := v.(*View); ok {
+		vector = view.vector
+		for i, index := range viewIndices {
+			viewIndices[i] = view.indices[index]
+		}
+	}
+
+	return &View{vector, viewIndices}
+}
+
+// Length is the number of values in the view.
+func (v View) Length() int {
+	return len(v.indices)
+}
+
+// Norm returns the L2-norm of the view.
+func (v View) Norm() float64 {
+	norm := 0.0
+	for _, index := range v.indices {
+		val := v.vector.Value(index)
+		norm += val * val
+	}
+
+	return math.Sqrt(norm)
+}
+
+// Value returns the value at the given index of the view.
+func (v View) Value(i int) float64 {
+	return v.vector.Value(v.indices[i])
+}
+
+// Opposite creates a new vector with the opposite values of the view.
+func (v View) Opposite() ReadOnlyVector {
+	return v.Scaled(-1.0)
+}
+
+// Scaled creates a new vector with the values of the view scaled by the given factor.
+func (v View) Scaled(factor float64) ReadOnlyVector {
+	scaled := makeVector(len(v.indices))
+	for i, index := range v.indices {
+		scaled.data[i] = v.vector.Value(index) * factor
+	}
+
+	return scaled
+}
+
+// Plus creates a new vector adding the view and another vector.
+func (v View) Plus(other ReadOnlyVector) ReadOnlyVector {
+	return operateWithVectors(v, other, func(a float64, b float64) float64 {
+		return a + b
+	})
+}
+
+// Minus creates a new vector subtracting another vector from the view.
+func (v View) Minus(other ReadOnlyVector) ReadOnlyVector {
+	return operateWithVectors(v, other, func(a float64, b float64) float64 {
+		return a - b
+	})
+}
+
+// Times multiplies the view and another vector as v' · other.
+func (v View) Times(other ReadOnlyVector) float64 {
+	if len(v.indices) != other.Length() {
+		panic("Cannot operate with vectors of different sizes")
+	}
+
+	result := 0.0
+	for i, index := range v.indices {
+		result += v.vector.Value(index) * other.Value(i)
+	}
+
+	return result
+}
+
+// Equals compares the view with a vector and returns true if they contain the same elements.
+func (v View) Equals(other ReadOnlyVector) bool {
+	if len(v.indices) != other.Length() {
+		return false
+	}
+
+	for i, index := range v.indices {
+		if !nums.FloatsEqual(v.vector.Value(index), other.Value(i)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Clone creates a new vector with a copy of the values in the view.
+func (v View) Clone() ReadOnlyVector {
+	return v.AsMutable()
+}
+
+// AsMutable returns a new vector with a copy of the values in the view.
+func (v View) AsMutable() MutableVector {
+	vector := makeVector(len(v.indices))
+	for i, index := range v.indices {
+		vector.data[i] = v.vector.Value(index)
+	}
+
+	return vector
+}
@@ -0,0 +1,86 @@
+package vec
+
+import "testing"
+
+func TestView(t *testing.T) {
+	v := MakeWithValues([]float64{1, 2, 3, 4, 5})
+
+	t.Run("values at the indices", func(t *testing.T) {
+		view := MakeView(v, []int{4, 0, 2})
+
+		if !VectorContainsData(view, []float64{5, 1, 3}) {
+			t.Errorf("Wrong view values: %v", view.AsMutable())
+		}
+		if got, want := view.Norm(), MakeWithValues([]float64{5, 1, 3}).Norm(); got != want {
+			t.Errorf("Wrong view norm: %f", got)
+		}
+	})
+
+	t.Run("operations", func(t *testing.T) {
+		var (
+			view  = MakeView(v, []int{0, 1})
+			other = MakeWithValues([]float64{10, 20})
+		)
+
+		if got := view.Plus(other); !VectorContainsData(got, []float64{11, 22}) {
+			t.Errorf("Wrong sum: %v", got)
+		}
+		if got := view.Minus(other); !VectorContainsData(got, []float64{-9, -18}) {
+			t.Errorf("Wrong subtraction: %v", got)
+		}
+		if got := view.Opposite(); !VectorContainsData(got, []float64{-1, -2}) {
+			t.Errorf("Wrong opposite: %v", got)
+		}
+		if got := view.Scaled(2); !VectorContainsData(got, []float64{2, 4}) {
+			t.Errorf("Wrong scaled vector: %v", got)
+		}
+		if got := view.Times(other); got != 50 {
+			t.Errorf("Want 50, got %f", got)
+		}
+		if !view.Equals(MakeWithValues([]float64{1, 2})) {
+			t.Error("Expected the view to equal the vector")
+		}
+	})
+
+	t.Run("views compose", func(t *testing.T) {
+		var (
+			outer = MakeView(v, []int{4, 3, 2})
+			inner = MakeView(outer, []int{2, 0})
+		)
+
+		if inner.vector != ReadOnlyVector(v) {
+			t.Error("Expected the composed view to be over the original vector")
+		}
+		if !VectorContainsData(inner, []float64{3, 5}) {
+			t.Errorf("Wrong view values: %v", inner.AsMutable())
+		}
+	})
+
+	t.Run("mutable copy", func(t *testing.T) {
+		var (
+			v       = MakeWithValues([]float64{1, 2})
+			view    = MakeView(v, []int{1})
+			mutable = view.AsMutable()
+		)
+
+		mutable.SetValue(0, 7)
+		if v.Value(1) != 2 {
+			t.Error("Expected AsMutable to return a copy")
+		}
+
+		v.SetValue(1, 3)
+		if view.Value(0) != 3 {
+			t.Error("Expected the view to see the changes in the vector")
+		}
+	})
+
+	t.Run("indices out of bounds", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected a panic")
+			}
+		}()
+
+		MakeView(v, []int{5})
+	})
+}